@@ -0,0 +1,50 @@
+package bench
+
+import (
+	"context"
+	"github.com/kubemq-io/kubemqctl/pkg/config"
+	"github.com/kubemq-io/kubemqctl/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+var benchExamples = `
+	# Execute 'queues' benchmark
+	kubemqctl bench queues
+
+	# Execute 'events' benchmark
+	kubemqctl bench events
+
+	# Execute 'events store' benchmark
+	kubemqctl bench events_store
+
+	# Execute 'commands' benchmark
+	kubemqctl bench commands
+
+	# Execute 'queries' benchmark
+	kubemqctl bench queries
+`
+var benchLong = `Executes Kubemq benchmark / load generator commands`
+var benchShort = `Executes Kubemq benchmark / load generator commands`
+
+func NewCmdBench(ctx context.Context, cfg *config.Config) *cobra.Command {
+
+	cmd := &cobra.Command{
+
+		Use:       "bench",
+		Aliases:   []string{"benchmark"},
+		Short:     benchShort,
+		Long:      benchLong,
+		Example:   benchExamples,
+		ValidArgs: []string{"queues", "events", "events_store", "commands", "queries"},
+		Run: func(cmd *cobra.Command, args []string) {
+			utils.CheckErr(cmd.Help())
+		},
+	}
+	cmd.AddCommand(NewCmdBenchQueues(ctx, cfg))
+	cmd.AddCommand(NewCmdBenchEvents(ctx, cfg))
+	cmd.AddCommand(NewCmdBenchEventsStore(ctx, cfg))
+	cmd.AddCommand(NewCmdBenchCommands(ctx, cfg))
+	cmd.AddCommand(NewCmdBenchQueries(ctx, cfg))
+
+	return cmd
+}
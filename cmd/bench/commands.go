@@ -0,0 +1,77 @@
+package bench
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	kubemq2 "github.com/kubemq-io/kubemq-go"
+	"github.com/kubemq-io/kubemqctl/pkg/config"
+	"github.com/spf13/cobra"
+	"time"
+)
+
+var benchCommandsExamples = `
+	# Run 'commands' benchmark for 10 seconds with 1 sender and 1 responder
+	kubemqctl bench commands
+
+	# Run 'commands' benchmark for 1 minute with 4 senders, 2 responders in a group and 1KB messages
+	kubemqctl bench commands -p 4 -s 2 -g G1 --size 1024 -d 1m
+
+	# Run 'commands' benchmark with 100 commands per second per sender and json report
+	kubemqctl bench commands -r 100 -o json
+`
+var benchCommandsLong = `Commands benchmark command allows to send and respond 'commands' and report throughput, round trip latency and errors`
+var benchCommandsShort = `Run 'commands' benchmark command`
+
+var commandsPattern = &benchPattern{
+	name: "commands",
+	rpc:  true,
+	publish: func(ctx context.Context, client *kubemq2.Client, o *BenchOptions, body []byte) error {
+		start := time.Now()
+		res, err := client.C().
+			SetChannel(o.channel).
+			SetId(uuid.New().String()).
+			SetBody(body).
+			SetTimeout(o.timeout).
+			Send(ctx)
+		if err != nil {
+			return err
+		}
+		if !res.Executed {
+			return fmt.Errorf("command not executed, %s", res.Error)
+		}
+		o.stats.respond(time.Since(start))
+		return nil
+	},
+	subscribe: func(ctx context.Context, client *kubemq2.Client, o *BenchOptions) error {
+		errChan := make(chan error, 1)
+		commandsChan, err := client.SubscribeToCommands(ctx, o.channel, o.group, errChan)
+		if err != nil {
+			return err
+		}
+		for {
+			select {
+			case command, opened := <-commandsChan:
+				if !opened {
+					return fmt.Errorf("server disconnected")
+				}
+				err := client.R().
+					SetRequestId(command.Id).
+					SetResponseTo(command.ResponseTo).
+					SetExecutedAt(time.Now()).
+					Send(ctx)
+				if err != nil && ctx.Err() == nil {
+					return err
+				}
+			case err := <-errChan:
+				return err
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	},
+}
+
+func NewCmdBenchCommands(ctx context.Context, cfg *config.Config) *cobra.Command {
+	return newBenchCommand(ctx, cfg, commandsPattern, "commands", benchCommandsShort, benchCommandsLong, benchCommandsExamples, "cmd", "command")
+}
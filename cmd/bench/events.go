@@ -0,0 +1,59 @@
+package bench
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	kubemq2 "github.com/kubemq-io/kubemq-go"
+	"github.com/kubemq-io/kubemqctl/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+var benchEventsExamples = `
+	# Run 'events' benchmark for 10 seconds with 1 publisher and 1 subscriber
+	kubemqctl bench events
+
+	# Run 'events' benchmark for 1 minute with 4 publishers, 2 subscribers and 1KB messages
+	kubemqctl bench events -p 4 -s 2 --size 1024 -d 1m
+
+	# Run 'events' benchmark with 1000 messages per second per publisher and json report
+	kubemqctl bench events -r 1000 -o json
+`
+var benchEventsLong = `Events benchmark command allows to publish and subscribe 'events' messages and report throughput, latency, errors and loss`
+var benchEventsShort = `Run 'events' benchmark command`
+
+var eventsPattern = &benchPattern{
+	name:   "events",
+	fanOut: true,
+	publish: func(ctx context.Context, client *kubemq2.Client, o *BenchOptions, body []byte) error {
+		return client.E().
+			SetChannel(o.channel).
+			SetId(uuid.New().String()).
+			SetBody(body).
+			Send(ctx)
+	},
+	subscribe: func(ctx context.Context, client *kubemq2.Client, o *BenchOptions) error {
+		errChan := make(chan error, 1)
+		eventsChan, err := client.SubscribeToEvents(ctx, o.channel, o.group, errChan)
+		if err != nil {
+			return err
+		}
+		for {
+			select {
+			case ev, opened := <-eventsChan:
+				if !opened {
+					return fmt.Errorf("server disconnected")
+				}
+				o.stats.receive(ev.Body)
+			case err := <-errChan:
+				return err
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	},
+}
+
+func NewCmdBenchEvents(ctx context.Context, cfg *config.Config) *cobra.Command {
+	return newBenchCommand(ctx, cfg, eventsPattern, "events", benchEventsShort, benchEventsLong, benchEventsExamples, "e", "ev")
+}
@@ -0,0 +1,66 @@
+package bench
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	kubemq2 "github.com/kubemq-io/kubemq-go"
+	"github.com/kubemq-io/kubemqctl/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+var benchEventsStoreExamples = `
+	# Run 'events store' benchmark for 10 seconds with 1 publisher and 1 subscriber
+	kubemqctl bench events_store
+
+	# Run 'events store' benchmark for 1 minute with 4 publishers, 2 subscribers and 1KB messages
+	kubemqctl bench events_store -p 4 -s 2 --size 1024 -d 1m
+
+	# Run 'events store' benchmark with 1000 messages per second per publisher and json report
+	kubemqctl bench events_store -r 1000 -o json
+`
+var benchEventsStoreLong = `Events store benchmark command allows to publish and subscribe 'events store' messages and report throughput, latency, errors and loss`
+var benchEventsStoreShort = `Run 'events store' benchmark command`
+
+var eventsStorePattern = &benchPattern{
+	name:   "events_store",
+	fanOut: true,
+	publish: func(ctx context.Context, client *kubemq2.Client, o *BenchOptions, body []byte) error {
+		res, err := client.ES().
+			SetChannel(o.channel).
+			SetId(uuid.New().String()).
+			SetBody(body).
+			Send(ctx)
+		if err != nil {
+			return err
+		}
+		if res.Err != nil {
+			return res.Err
+		}
+		return nil
+	},
+	subscribe: func(ctx context.Context, client *kubemq2.Client, o *BenchOptions) error {
+		errChan := make(chan error, 1)
+		eventsChan, err := client.SubscribeToEventsStore(ctx, o.channel, o.group, errChan, kubemq2.StartFromNewEvents())
+		if err != nil {
+			return err
+		}
+		for {
+			select {
+			case ev, opened := <-eventsChan:
+				if !opened {
+					return fmt.Errorf("server disconnected")
+				}
+				o.stats.receive(ev.Body)
+			case err := <-errChan:
+				return err
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	},
+}
+
+func NewCmdBenchEventsStore(ctx context.Context, cfg *config.Config) *cobra.Command {
+	return newBenchCommand(ctx, cfg, eventsStorePattern, "events_store", benchEventsStoreShort, benchEventsStoreLong, benchEventsStoreExamples, "es")
+}
@@ -0,0 +1,202 @@
+package bench
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	kubemq2 "github.com/kubemq-io/kubemq-go"
+	"github.com/kubemq-io/kubemqctl/pkg/config"
+	"github.com/kubemq-io/kubemqctl/pkg/k8s"
+	"github.com/kubemq-io/kubemqctl/pkg/kubemq"
+	"github.com/kubemq-io/kubemqctl/pkg/utils"
+	"github.com/spf13/cobra"
+	"sync"
+	"time"
+)
+
+// timestampSize is the size of the send timestamp embedded at the start of each message body
+const timestampSize = 8
+
+type benchPattern struct {
+	name string
+	// rpc patterns measure the latency by the publisher round trip, others by the subscriber arrival time
+	rpc bool
+	// fanOut patterns deliver each message to every subscriber which is not in a group
+	fanOut    bool
+	publish   func(ctx context.Context, client *kubemq2.Client, o *BenchOptions, body []byte) error
+	subscribe func(ctx context.Context, client *kubemq2.Client, o *BenchOptions) error
+}
+
+type BenchOptions struct {
+	cfg         *config.Config
+	transport   string
+	pattern     *benchPattern
+	channel     string
+	group       string
+	publishers  int
+	subscribers int
+	size        int
+	rate        int
+	duration    time.Duration
+	drain       time.Duration
+	timeout     time.Duration
+	stats       *stats
+}
+
+func newBenchCommand(ctx context.Context, cfg *config.Config, pattern *benchPattern, use, short, long, examples string, aliases ...string) *cobra.Command {
+	o := &BenchOptions{
+		cfg:     cfg,
+		pattern: pattern,
+	}
+	cmd := &cobra.Command{
+
+		Use:     use,
+		Aliases: aliases,
+		Short:   short,
+		Long:    long,
+		Example: examples,
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			utils.CheckErr(o.Complete(args, cfg.ConnectionType), cmd)
+			utils.CheckErr(o.Validate())
+			utils.CheckErr(k8s.SetTransport(ctx, cfg))
+			utils.CheckErr(o.Run(ctx))
+		},
+	}
+	cmd.PersistentFlags().StringVarP(&o.channel, "channel", "c", fmt.Sprintf("bench-%s", pattern.name), "set benchmark channel")
+	cmd.PersistentFlags().StringVarP(&o.group, "group", "g", "", "set subscribers group")
+	cmd.PersistentFlags().IntVarP(&o.publishers, "publishers", "p", 1, "set how many concurrent publishers to run")
+	cmd.PersistentFlags().IntVarP(&o.subscribers, "subscribers", "s", 1, "set how many concurrent subscribers to run")
+	cmd.PersistentFlags().IntVarP(&o.size, "size", "", 100, "set message body size in bytes")
+	cmd.PersistentFlags().IntVarP(&o.rate, "rate", "r", 0, "set messages per second of each publisher, 0 sends as fast as possible")
+	cmd.PersistentFlags().DurationVarP(&o.duration, "duration", "d", 10*time.Second, "set benchmark publishing duration")
+	cmd.PersistentFlags().DurationVarP(&o.drain, "drain", "", 2*time.Second, "set how long to wait for in flight messages after publishing ends")
+	cmd.PersistentFlags().DurationVarP(&o.timeout, "timeout", "t", 10*time.Second, "set request timeout of 'commands' and 'queries'")
+	return cmd
+}
+
+func (o *BenchOptions) Complete(args []string, transport string) error {
+	o.transport = transport
+	o.stats = newStats()
+	return nil
+}
+
+func (o *BenchOptions) Validate() error {
+	if o.publishers <= 0 {
+		return fmt.Errorf("publishers must be greater than 0")
+	}
+	if o.subscribers <= 0 {
+		return fmt.Errorf("subscribers must be greater than 0")
+	}
+	if o.size < timestampSize {
+		return fmt.Errorf("message size must be at least %d bytes", timestampSize)
+	}
+	if o.rate < 0 {
+		return fmt.Errorf("rate cannot be negative")
+	}
+	if o.duration <= 0 {
+		return fmt.Errorf("duration must be greater than 0")
+	}
+	return nil
+}
+
+func (o *BenchOptions) Run(ctx context.Context) error {
+	subCtx, subCancel := context.WithCancel(ctx)
+	defer subCancel()
+	subWg := sync.WaitGroup{}
+	for i := 0; i < o.subscribers; i++ {
+		client, err := kubemq.GetKubemqClient(ctx, o.transport, o.cfg)
+		if err != nil {
+			return fmt.Errorf("create kubemq client, %s", err.Error())
+		}
+		defer client.Close()
+		subWg.Add(1)
+		go func() {
+			defer subWg.Done()
+			if err := o.pattern.subscribe(subCtx, client, o); err != nil && subCtx.Err() == nil {
+				utils.Printlnf("subscriber error, %s", err.Error())
+			}
+		}()
+	}
+	// let the subscriptions settle before publishing
+	time.Sleep(time.Second)
+	utils.Printlnf("running '%s' benchmark on %s channel for %s with %d publishers and %d subscribers...", o.pattern.name, o.channel, o.duration, o.publishers, o.subscribers)
+	pubCtx, pubCancel := context.WithTimeout(ctx, o.duration)
+	defer pubCancel()
+	pubWg := sync.WaitGroup{}
+	start := time.Now()
+	for i := 0; i < o.publishers; i++ {
+		client, err := kubemq.GetKubemqClient(ctx, o.transport, o.cfg)
+		if err != nil {
+			return fmt.Errorf("create kubemq client, %s", err.Error())
+		}
+		defer client.Close()
+		pubWg.Add(1)
+		go func() {
+			defer pubWg.Done()
+			o.publisher(pubCtx, client)
+		}()
+	}
+	pubWg.Wait()
+	elapsed := time.Since(start)
+	if !o.pattern.rpc {
+		utils.Printlnf("publishing completed, waiting %s for in flight messages...", o.drain)
+		select {
+		case <-time.After(o.drain):
+		case <-ctx.Done():
+		}
+	}
+	subCancel()
+	subWg.Wait()
+	return o.report(elapsed).print()
+}
+
+func (o *BenchOptions) publisher(ctx context.Context, client *kubemq2.Client) {
+	var ticker *time.Ticker
+	if o.rate > 0 {
+		ticker = time.NewTicker(time.Second / time.Duration(o.rate))
+		defer ticker.Stop()
+	}
+	for {
+		if ticker != nil {
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		err := o.pattern.publish(ctx, client, o, newBody(o.size))
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			o.stats.error()
+			continue
+		}
+		o.stats.send()
+	}
+}
+
+func (o *BenchOptions) expected(sent int64) int64 {
+	if o.pattern.fanOut && o.group == "" {
+		return sent * int64(o.subscribers)
+	}
+	return sent
+}
+
+func newBody(size int) []byte {
+	body := make([]byte, size)
+	binary.BigEndian.PutUint64(body, uint64(time.Now().UnixNano()))
+	return body
+}
+
+func sentAt(body []byte) (time.Time, bool) {
+	if len(body) < timestampSize {
+		return time.Time{}, false
+	}
+	return time.Unix(0, int64(binary.BigEndian.Uint64(body))), true
+}
@@ -0,0 +1,78 @@
+package bench
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	kubemq2 "github.com/kubemq-io/kubemq-go"
+	"github.com/kubemq-io/kubemqctl/pkg/config"
+	"github.com/spf13/cobra"
+	"time"
+)
+
+var benchQueriesExamples = `
+	# Run 'queries' benchmark for 10 seconds with 1 sender and 1 responder
+	kubemqctl bench queries
+
+	# Run 'queries' benchmark for 1 minute with 4 senders, 2 responders in a group and 1KB messages
+	kubemqctl bench queries -p 4 -s 2 -g G1 --size 1024 -d 1m
+
+	# Run 'queries' benchmark with 100 queries per second per sender and json report
+	kubemqctl bench queries -r 100 -o json
+`
+var benchQueriesLong = `Queries benchmark command allows to send and respond 'queries' and report throughput, round trip latency and errors`
+var benchQueriesShort = `Run 'queries' benchmark command`
+
+var queriesPattern = &benchPattern{
+	name: "queries",
+	rpc:  true,
+	publish: func(ctx context.Context, client *kubemq2.Client, o *BenchOptions, body []byte) error {
+		start := time.Now()
+		res, err := client.Q().
+			SetChannel(o.channel).
+			SetId(uuid.New().String()).
+			SetBody(body).
+			SetTimeout(o.timeout).
+			Send(ctx)
+		if err != nil {
+			return err
+		}
+		if !res.Executed {
+			return fmt.Errorf("query not executed, %s", res.Error)
+		}
+		o.stats.respond(time.Since(start))
+		return nil
+	},
+	subscribe: func(ctx context.Context, client *kubemq2.Client, o *BenchOptions) error {
+		errChan := make(chan error, 1)
+		queriesChan, err := client.SubscribeToQueries(ctx, o.channel, o.group, errChan)
+		if err != nil {
+			return err
+		}
+		for {
+			select {
+			case query, opened := <-queriesChan:
+				if !opened {
+					return fmt.Errorf("server disconnected")
+				}
+				err := client.R().
+					SetRequestId(query.Id).
+					SetResponseTo(query.ResponseTo).
+					SetExecutedAt(time.Now()).
+					SetBody(query.Body).
+					Send(ctx)
+				if err != nil && ctx.Err() == nil {
+					return err
+				}
+			case err := <-errChan:
+				return err
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	},
+}
+
+func NewCmdBenchQueries(ctx context.Context, cfg *config.Config) *cobra.Command {
+	return newBenchCommand(ctx, cfg, queriesPattern, "queries", benchQueriesShort, benchQueriesLong, benchQueriesExamples, "qry", "query")
+}
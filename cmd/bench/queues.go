@@ -0,0 +1,67 @@
+package bench
+
+import (
+	"context"
+	"fmt"
+	kubemq2 "github.com/kubemq-io/kubemq-go"
+	"github.com/kubemq-io/kubemqctl/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+// queuesReceiveBatch is the max messages each 'queues' subscriber pulls in a single request
+const queuesReceiveBatch = 100
+
+var benchQueuesExamples = `
+	# Run 'queues' benchmark for 10 seconds with 1 publisher and 1 subscriber
+	kubemqctl bench queues
+
+	# Run 'queues' benchmark for 1 minute with 4 publishers, 2 subscribers and 1KB messages
+	kubemqctl bench queues -p 4 -s 2 --size 1024 -d 1m
+
+	# Run 'queues' benchmark with 1000 messages per second per publisher and json report
+	kubemqctl bench queues -r 1000 -o json
+`
+var benchQueuesLong = `Queues benchmark command allows to send and receive 'queues' messages and report throughput, latency, errors and loss`
+var benchQueuesShort = `Run 'queues' benchmark command`
+
+var queuesPattern = &benchPattern{
+	name: "queues",
+	publish: func(ctx context.Context, client *kubemq2.Client, o *BenchOptions, body []byte) error {
+		res, err := client.QM().
+			SetChannel(o.channel).
+			SetBody(body).
+			Send(ctx)
+		if err != nil {
+			return err
+		}
+		if res.IsError {
+			return fmt.Errorf("send 'queues' message, %s", res.Error)
+		}
+		return nil
+	},
+	subscribe: func(ctx context.Context, client *kubemq2.Client, o *BenchOptions) error {
+		for {
+			res, err := client.RQM().
+				SetChannel(o.channel).
+				SetWaitTimeSeconds(1).
+				SetMaxNumberOfMessages(queuesReceiveBatch).
+				Send(ctx)
+			if ctx.Err() != nil {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if res.IsError {
+				return fmt.Errorf("receive 'queues' messages, %s", res.Error)
+			}
+			for _, msg := range res.Messages {
+				o.stats.receive(msg.Body)
+			}
+		}
+	},
+}
+
+func NewCmdBenchQueues(ctx context.Context, cfg *config.Config) *cobra.Command {
+	return newBenchCommand(ctx, cfg, queuesPattern, "queues", benchQueuesShort, benchQueuesLong, benchQueuesExamples, "q", "queue")
+}
@@ -0,0 +1,185 @@
+package bench
+
+import (
+	"fmt"
+	"github.com/kubemq-io/kubemqctl/pkg/output"
+	"math"
+	"os"
+	"sync"
+	"sync/atomic"
+	"text/tabwriter"
+	"time"
+)
+
+const (
+	// latencyBucketGrowth is the ratio between the upper bounds of consecutive latency buckets, percentiles are within 1% of the exact value
+	latencyBucketGrowth = 1.01
+	// latencyBuckets covers latencies from 1 microsecond up to one hour, longer latencies are counted in the last bucket
+	latencyBuckets = 2212
+)
+
+var latencyBucketLog = math.Log(latencyBucketGrowth)
+
+// latencyHistogram counts latencies in exponential buckets, so long benchmark runs use a fixed amount of memory
+type latencyHistogram struct {
+	counts [latencyBuckets]int64
+	total  int64
+	max    time.Duration
+}
+
+func latencyBucket(d time.Duration) int {
+	us := float64(d) / float64(time.Microsecond)
+	if us <= 1 {
+		return 0
+	}
+	idx := int(math.Ceil(math.Log(us) / latencyBucketLog))
+	if idx >= latencyBuckets {
+		return latencyBuckets - 1
+	}
+	return idx
+}
+
+func (h *latencyHistogram) add(d time.Duration) {
+	h.counts[latencyBucket(d)]++
+	h.total++
+	if d > h.max {
+		h.max = d
+	}
+}
+
+// percentile returns the upper bound of the bucket of the p percentile latency in milliseconds, capped by the max latency which is kept exact
+func (h *latencyHistogram) percentile(p float64) float64 {
+	if h.total == 0 {
+		return 0
+	}
+	rank := int64(math.Ceil(p * float64(h.total)))
+	if rank < 1 {
+		rank = 1
+	}
+	var count int64
+	for idx, n := range h.counts {
+		count += n
+		if count >= rank {
+			bound := time.Duration(math.Pow(latencyBucketGrowth, float64(idx)) * float64(time.Microsecond))
+			if bound > h.max || idx == latencyBuckets-1 {
+				bound = h.max
+			}
+			return float64(bound) / float64(time.Millisecond)
+		}
+	}
+	return float64(h.max) / float64(time.Millisecond)
+}
+
+type stats struct {
+	sent      int64
+	received  int64
+	errors    int64
+	mu        sync.Mutex
+	latencies *latencyHistogram
+}
+
+func newStats() *stats {
+	return &stats{
+		latencies: &latencyHistogram{},
+	}
+}
+
+func (s *stats) send() {
+	atomic.AddInt64(&s.sent, 1)
+}
+
+func (s *stats) error() {
+	atomic.AddInt64(&s.errors, 1)
+}
+
+// receive records a received message and its latency from the send timestamp embedded in the body
+func (s *stats) receive(body []byte) {
+	atomic.AddInt64(&s.received, 1)
+	if ts, ok := sentAt(body); ok {
+		s.latency(time.Since(ts))
+	}
+}
+
+// respond records a successful rpc round trip and its latency
+func (s *stats) respond(d time.Duration) {
+	atomic.AddInt64(&s.received, 1)
+	s.latency(d)
+}
+
+func (s *stats) latency(d time.Duration) {
+	s.mu.Lock()
+	s.latencies.add(d)
+	s.mu.Unlock()
+}
+
+type benchReport struct {
+	Pattern     string  `json:"pattern"`
+	Channel     string  `json:"channel"`
+	Publishers  int     `json:"publishers"`
+	Subscribers int     `json:"subscribers"`
+	MessageSize int     `json:"message_size"`
+	Rate        int     `json:"rate"`
+	Duration    float64 `json:"duration_seconds"`
+	Sent        int64   `json:"sent"`
+	Received    int64   `json:"received"`
+	Errors      int64   `json:"errors"`
+	Lost        int64   `json:"lost"`
+	SendRate    float64 `json:"send_rate"`
+	ReceiveRate float64 `json:"receive_rate"`
+	Throughput  float64 `json:"throughput_bytes"`
+	LatencyP50  float64 `json:"latency_p50_ms"`
+	LatencyP95  float64 `json:"latency_p95_ms"`
+	LatencyP99  float64 `json:"latency_p99_ms"`
+	LatencyMax  float64 `json:"latency_max_ms"`
+}
+
+func (o *BenchOptions) report(elapsed time.Duration) *benchReport {
+	s := o.stats
+	s.mu.Lock()
+	latencies := *s.latencies
+	s.mu.Unlock()
+	seconds := elapsed.Seconds()
+	r := &benchReport{
+		Pattern:     o.pattern.name,
+		Channel:     o.channel,
+		Publishers:  o.publishers,
+		Subscribers: o.subscribers,
+		MessageSize: o.size,
+		Rate:        o.rate,
+		Duration:    seconds,
+		Sent:        atomic.LoadInt64(&s.sent),
+		Received:    atomic.LoadInt64(&s.received),
+		Errors:      atomic.LoadInt64(&s.errors),
+		LatencyP50:  latencies.percentile(0.50),
+		LatencyP95:  latencies.percentile(0.95),
+		LatencyP99:  latencies.percentile(0.99),
+		LatencyMax:  latencies.percentile(1),
+	}
+	if lost := o.expected(r.Sent) - r.Received; lost > 0 {
+		r.Lost = lost
+	}
+	if seconds > 0 {
+		r.SendRate = float64(r.Sent) / seconds
+		r.ReceiveRate = float64(r.Received) / seconds
+		r.Throughput = r.ReceiveRate * float64(o.size)
+	}
+	return r
+}
+
+func (r *benchReport) print() error {
+	if !output.IsText() {
+		return output.Print(r)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.TabIndent)
+	fmt.Fprintf(w, "PATTERN:\t%s\n", r.Pattern)
+	fmt.Fprintf(w, "CHANNEL:\t%s\n", r.Channel)
+	fmt.Fprintf(w, "PUBLISHERS / SUBSCRIBERS:\t%d / %d\n", r.Publishers, r.Subscribers)
+	fmt.Fprintf(w, "MESSAGE SIZE:\t%d bytes\n", r.MessageSize)
+	fmt.Fprintf(w, "DURATION:\t%.2fs\n", r.Duration)
+	fmt.Fprintf(w, "SENT / RECEIVED:\t%d / %d\n", r.Sent, r.Received)
+	fmt.Fprintf(w, "ERRORS / LOST:\t%d / %d\n", r.Errors, r.Lost)
+	fmt.Fprintf(w, "SEND RATE:\t%.0f msgs/sec\n", r.SendRate)
+	fmt.Fprintf(w, "RECEIVE RATE:\t%.0f msgs/sec (%.0f bytes/sec)\n", r.ReceiveRate, r.Throughput)
+	fmt.Fprintf(w, "LATENCY P50 / P95 / P99 / MAX:\t%.2fms / %.2fms / %.2fms / %.2fms\n", r.LatencyP50, r.LatencyP95, r.LatencyP99, r.LatencyMax)
+	return w.Flush()
+}
@@ -0,0 +1,98 @@
+package bench
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatencyHistogram_Percentile(t *testing.T) {
+	h := &latencyHistogram{}
+	require.Equal(t, 0.0, h.percentile(0.5))
+
+	rnd := rand.New(rand.NewSource(1))
+	var exact []time.Duration
+	for i := 0; i < 100000; i++ {
+		d := time.Duration(rnd.ExpFloat64() * float64(5*time.Millisecond))
+		exact = append(exact, d)
+		h.add(d)
+	}
+	sort.Slice(exact, func(i, j int) bool { return exact[i] < exact[j] })
+	for _, p := range []float64{0.5, 0.95, 0.99} {
+		want := float64(exact[int(float64(len(exact))*p)-1]) / float64(time.Millisecond)
+		require.InEpsilon(t, want, h.percentile(p), 0.011, "p%v", p)
+	}
+	require.Equal(t, float64(exact[len(exact)-1])/float64(time.Millisecond), h.percentile(1))
+}
+
+func TestLatencyHistogram_Bounds(t *testing.T) {
+	h := &latencyHistogram{}
+	h.add(0)
+	h.add(500 * time.Nanosecond)
+	require.Equal(t, int64(2), h.counts[0])
+	require.Equal(t, 0.0005, h.percentile(1))
+
+	h.add(2 * time.Hour)
+	require.Equal(t, int64(1), h.counts[latencyBuckets-1])
+	require.Equal(t, float64(2*time.Hour)/float64(time.Millisecond), h.percentile(1))
+
+	h = &latencyHistogram{}
+	h.add(10 * time.Millisecond)
+	require.Equal(t, 10.0, h.percentile(0.5))
+}
+
+func TestBenchOptions_Report(t *testing.T) {
+	tests := []struct {
+		name        string
+		fanOut      bool
+		group       string
+		subscribers int
+		sent        int64
+		received    int64
+		lost        int64
+	}{
+		{"single delivery", false, "", 3, 100, 90, 10},
+		{"fan out to every subscriber", true, "", 3, 100, 290, 10},
+		{"fan out in a group", true, "g1", 3, 100, 100, 0},
+		{"received more than expected", false, "", 1, 100, 120, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &BenchOptions{
+				pattern:     &benchPattern{name: "events", fanOut: tt.fanOut},
+				channel:     "bench",
+				group:       tt.group,
+				publishers:  2,
+				subscribers: tt.subscribers,
+				size:        100,
+				rate:        50,
+				stats:       newStats(),
+			}
+			o.stats.sent = tt.sent
+			o.stats.received = tt.received
+			o.stats.errors = 1
+			o.stats.latency(2 * time.Millisecond)
+			o.stats.latency(4 * time.Millisecond)
+			r := o.report(2 * time.Second)
+			require.Equal(t, "events", r.Pattern)
+			require.Equal(t, 2.0, r.Duration)
+			require.Equal(t, tt.sent, r.Sent)
+			require.Equal(t, tt.received, r.Received)
+			require.Equal(t, int64(1), r.Errors)
+			require.Equal(t, tt.lost, r.Lost)
+			require.Equal(t, float64(tt.sent)/2, r.SendRate)
+			require.Equal(t, float64(tt.received)/2, r.ReceiveRate)
+			require.Equal(t, float64(tt.received)/2*100, r.Throughput)
+			require.InEpsilon(t, 2.0, r.LatencyP50, 0.01)
+			require.Equal(t, 4.0, r.LatencyMax)
+		})
+	}
+
+	o := &BenchOptions{pattern: &benchPattern{name: "queues"}, stats: newStats()}
+	r := o.report(0)
+	require.Equal(t, 0.0, r.SendRate)
+	require.Equal(t, 0.0, r.LatencyP99)
+}
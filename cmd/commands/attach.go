@@ -6,15 +6,20 @@ import (
 	"github.com/kubemq-io/kubemqctl/pkg/attach"
 	"github.com/kubemq-io/kubemqctl/pkg/config"
 	"github.com/kubemq-io/kubemqctl/pkg/k8s"
+	"github.com/kubemq-io/kubemqctl/pkg/match"
+	"github.com/kubemq-io/kubemqctl/pkg/output"
 	"github.com/kubemq-io/kubemqctl/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
 type CommandsAttachOptions struct {
-	cfg       *config.Config
-	include   []string
-	exclude   []string
-	resources []string
+	cfg        *config.Config
+	include    []string
+	exclude    []string
+	resources  []string
+	bodyOutput output.BodyOptions
+	filter     string
+	filterExpr *match.Filter
 }
 
 var commandsAttachExamples = `
@@ -59,10 +64,20 @@ func NewCmdCommandsAttach(ctx context.Context, cfg *config.Config) *cobra.Comman
 
 	cmd.PersistentFlags().StringArrayVarP(&o.include, "include", "i", []string{}, "Set (regex) strings to include")
 	cmd.PersistentFlags().StringArrayVarP(&o.exclude, "exclude", "e", []string{}, "Set (regex) strings to exclude")
+	cmd.PersistentFlags().StringVarP(&o.filter, "filter", "", "", "set filter expression over body json paths, metadata, tags, channel, client_id and id, i.e. body.order.total > 100 && tags.region == \"eu\", body paths select fields of the attached message json, messages which do not match are not printed")
+	o.bodyOutput.AddFlags(cmd)
 	return cmd
 }
 
 func (o *CommandsAttachOptions) Complete(args []string, transport string) error {
+	if err := o.bodyOutput.Complete(); err != nil {
+		return err
+	}
+	filter, err := match.ParseFilter(o.filter)
+	if err != nil {
+		return err
+	}
+	o.filterExpr = filter
 
 	if len(args) == 0 {
 		return fmt.Errorf("missing channel argument")
@@ -81,7 +96,7 @@ func (o *CommandsAttachOptions) Validate() error {
 }
 
 func (o *CommandsAttachOptions) Run(ctx context.Context) error {
-	err := attach.Run(ctx, o.cfg, o.resources, o.include, o.exclude)
+	err := attach.Run(ctx, o.cfg, o.resources, o.include, o.exclude, o.filterExpr)
 	if err != nil {
 		return err
 	}
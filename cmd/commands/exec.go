@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	kubemq2 "github.com/kubemq-io/kubemq-go"
+	"github.com/kubemq-io/kubemqctl/pkg/utils"
+)
+
+// runHandler runs the exec handler for a command and sends the response, a handler exit status 0 is an executed response, any other status is an error response with the handler stderr as the error text
+func (o *CommandsReceiveOptions) runHandler(ctx context.Context, client *kubemq2.Client, command *kubemq2.CommandReceive) {
+	start := time.Now()
+	err := o.exec(ctx, command)
+	resp := client.R().SetRequestId(command.Id).SetResponseTo(command.ResponseTo)
+	if err != nil {
+		utils.Printlnf("command %s failed, %s", command.Id, err.Error())
+		resp.SetError(err)
+	} else {
+		utils.Printlnf("command %s executed in %s", command.Id, time.Since(start))
+		resp.SetExecutedAt(time.Now())
+	}
+	if err := resp.Send(ctx); err != nil {
+		utils.Printlnf("send command %s response error, %s", command.Id, err.Error())
+	}
+}
+
+// exec runs the handler in its own process group and kills the group on timeout, the handler stdout is buffered and printed
+// once the handler exits, so the output of concurrent handlers does not interleave with each other or with the received commands
+func (o *CommandsReceiveOptions) exec(ctx context.Context, command *kubemq2.CommandReceive) error {
+	ctx, cancel := context.WithTimeout(ctx, o.execTimeout)
+	defer cancel()
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	cmd := exec.Command(o.handler[0], o.handler[1:]...)
+	cmd.Stdin = bytes.NewReader(command.Body)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.Env = append(os.Environ(), commandEnv(command)...)
+	setProcessGroup(cmd)
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("handler failed, %s", err.Error())
+	}
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			killProcessGroup(cmd)
+		case <-done:
+		}
+	}()
+	err := cmd.Wait()
+	close(done)
+	if text := strings.TrimRight(stdout.String(), "\n"); text != "" {
+		utils.PrintlnfNoTitle("%s", text)
+	}
+	if err == nil {
+		return nil
+	}
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("handler timeout after %s", o.execTimeout)
+	}
+	if text := strings.TrimSpace(stderr.String()); text != "" {
+		return errors.New(text)
+	}
+	return fmt.Errorf("handler failed, %s", err.Error())
+}
+
+func commandEnv(command *kubemq2.CommandReceive) []string {
+	env := []string{
+		fmt.Sprintf("KUBEMQ_CHANNEL=%s", command.Channel),
+		fmt.Sprintf("KUBEMQ_COMMAND_ID=%s", command.Id),
+		fmt.Sprintf("KUBEMQ_CLIENT_ID=%s", command.ClientId),
+		fmt.Sprintf("KUBEMQ_METADATA=%s", command.Metadata),
+	}
+	for key, value := range command.Tags {
+		env = append(env, fmt.Sprintf("KUBEMQ_TAG_%s=%s", utils.EnvKey(key), value))
+	}
+	return env
+}
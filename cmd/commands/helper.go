@@ -1,10 +1,10 @@
 package commands
 
 import (
-	b64 "encoding/base64"
 	"encoding/json"
-	"fmt"
 	kubemq "github.com/kubemq-io/kubemq-go"
+	"github.com/kubemq-io/kubemqctl/pkg/match"
+	"github.com/kubemq-io/kubemqctl/pkg/output"
 	"strconv"
 )
 
@@ -18,8 +18,7 @@ type object struct {
 	Timeout    string            `json:"timeout,omitempty"`
 	ExecutedAt string            `json:"executed_at,omitempty"`
 	Error      string            `json:"error,omitempty"`
-	BodyJson   json.RawMessage   `json:"body_json,omitempty"`
-	BodyString string            `json:"body_string,omitempty"`
+	output.Body
 }
 
 func newObjectWithCommandReceive(cmd *kubemq.CommandReceive) *object {
@@ -29,25 +28,13 @@ func newObjectWithCommandReceive(cmd *kubemq.CommandReceive) *object {
 		ClientId:   cmd.ClientId,
 		Metadata:   cmd.Metadata,
 		Tags:       cmd.Tags,
-		BodyJson:   json.RawMessage{},
-		BodyString: "",
 		Executed:   "",
 		ExecutedAt: "",
 		Error:      "",
 		Timeout:    "",
 	}
 
-	var js json.RawMessage
-	if err := json.Unmarshal(cmd.Body, &js); err == nil {
-		obj.BodyJson = js
-	} else {
-		sDec, err := b64.StdEncoding.DecodeString(string(cmd.Body))
-		if err != nil {
-			obj.BodyString = string(cmd.Body)
-		} else {
-			obj.BodyString = string(sDec)
-		}
-	}
+	obj.Body = output.NewBody(cmd.Body)
 	return obj
 }
 func newObjectWithCommandResponse(response *kubemq.CommandResponse) *object {
@@ -61,8 +48,6 @@ func newObjectWithCommandResponse(response *kubemq.CommandResponse) *object {
 		Timeout:    "",
 		ExecutedAt: response.ExecutedAt.Format("2006-01-02 15:04:05.999"),
 		Error:      response.Error,
-		BodyJson:   nil,
-		BodyString: "",
 	}
 	if !response.Executed {
 		obj.ExecutedAt = ""
@@ -77,24 +62,12 @@ func newObjectWithCommand(cmd *kubemq.Command) *object {
 		ClientId:   cmd.ClientId,
 		Metadata:   cmd.Metadata,
 		Tags:       cmd.Tags,
-		BodyJson:   json.RawMessage{},
-		BodyString: "",
 		Executed:   "",
 		ExecutedAt: "",
 		Error:      "",
 		Timeout:    cmd.Timeout.String(),
 	}
-	var js json.RawMessage
-	if err := json.Unmarshal(cmd.Body, &js); err == nil {
-		obj.BodyJson = js
-	} else {
-		sDec, err := b64.StdEncoding.DecodeString(string(cmd.Body))
-		if err != nil {
-			obj.BodyString = string(cmd.Body)
-		} else {
-			obj.BodyString = string(sDec)
-		}
-	}
+	obj.Body = output.NewBody(cmd.Body)
 
 	return obj
 }
@@ -104,13 +77,24 @@ func (o *object) String() string {
 	return string(data)
 }
 
+func newFilterMessage(cmd *kubemq.CommandReceive) *match.Message {
+	return &match.Message{
+		Id:       cmd.Id,
+		Channel:  cmd.Channel,
+		ClientId: cmd.ClientId,
+		Metadata: cmd.Metadata,
+		Tags:     cmd.Tags,
+		Body:     cmd.Body,
+	}
+}
+
 func printCommandReceive(command *kubemq.CommandReceive) {
-	fmt.Println(newObjectWithCommandReceive(command))
+	output.Message(newObjectWithCommandReceive(command))
 }
 
 func printCommandResponse(response *kubemq.CommandResponse) {
-	fmt.Println(newObjectWithCommandResponse(response))
+	output.Message(newObjectWithCommandResponse(response))
 }
 func printCommand(cmd *kubemq.Command) {
-	fmt.Println(newObjectWithCommand(cmd))
+	output.Message(newObjectWithCommand(cmd))
 }
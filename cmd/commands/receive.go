@@ -7,8 +7,12 @@ import (
 	"github.com/kubemq-io/kubemqctl/pkg/config"
 	"github.com/kubemq-io/kubemqctl/pkg/k8s"
 	"github.com/kubemq-io/kubemqctl/pkg/kubemq"
+	"github.com/kubemq-io/kubemqctl/pkg/match"
+	"github.com/kubemq-io/kubemqctl/pkg/output"
 	"github.com/kubemq-io/kubemqctl/pkg/utils"
 	"github.com/spf13/cobra"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -18,6 +22,16 @@ type CommandsReceiveOptions struct {
 	channel      string
 	group        string
 	autoResponse bool
+	reconnect    bool
+	execStr      string
+	handler      []string
+	execTimeout  time.Duration
+	concurrency  int
+	bodyOutput   output.BodyOptions
+	tagFilters   []string
+	tagFilter    match.TagFilter
+	filter       string
+	filterExpr   *match.Filter
 }
 
 var commandsReceiveExamples = `
@@ -26,6 +40,15 @@ var commandsReceiveExamples = `
 
 	# Receive commands from a 'commands' channel with group (blocks until next body)
 	kubemqctl commands receive some-channel -g G1
+
+	# Receive commands from a 'commands' channel and exit when the server disconnects
+	kubemqctl commands receive some-channel --reconnect=false
+
+	# Receive commands from a 'commands' channel and run ./handler.sh for each command, the command body is piped to the handler stdin
+	kubemqctl commands receive some-channel --exec ./handler.sh
+
+	# Receive commands from a 'commands' channel and run up to 10 handlers concurrently with 5 seconds timeout each
+	kubemqctl commands receive some-channel --exec "./handler.sh --verbose" --concurrency 10 --exec-timeout 5s
 `
 var commandsReceiveLong = `Receive (Subscribe) command allows to consume a body from 'commands' channel and response with appropriate reply`
 var commandsReceiveShort = `Receive a body from 'commands' channel command`
@@ -53,19 +76,61 @@ func NewCmdCommandsReceive(ctx context.Context, cfg *config.Config) *cobra.Comma
 
 	cmd.PersistentFlags().StringVarP(&o.group, "group", "g", "", "set 'commands' channel consumer group (load balancing)")
 	cmd.PersistentFlags().BoolVarP(&o.autoResponse, "auto-response", "a", false, "set auto response executed command for each command received")
+	cmd.PersistentFlags().BoolVarP(&o.reconnect, "reconnect", "", true, "set auto reconnect with exponential backoff when the subscription disconnects")
+	cmd.PersistentFlags().StringVarP(&o.execStr, "exec", "", "", "set handler to run for each command received, the command body is piped to the handler stdin and the handler stdout is printed when it exits, exit status 0 responses executed, otherwise the handler stderr is the response error")
+	cmd.PersistentFlags().DurationVarP(&o.execTimeout, "exec-timeout", "", 30*time.Second, "set handler timeout for each command, the handler and the processes it started are killed on timeout")
+	cmd.PersistentFlags().IntVarP(&o.concurrency, "concurrency", "c", 1, "set how many handlers to run concurrently")
+	cmd.PersistentFlags().StringArrayVarP(&o.tagFilters, "tag-filter", "", []string{}, "set handle only commands with tags matching regexes in key=regex format, repeat to filter by several tags, other commands are left without a response")
+	cmd.PersistentFlags().StringVarP(&o.filter, "filter", "", "", "set filter expression over body json paths, metadata, tags, channel, client_id and id, i.e. body.order.total > 100 && tags.region == \"eu\", commands which do not match are left without a response")
+	o.bodyOutput.AddFlags(cmd)
 	return cmd
 }
 
 func (o *CommandsReceiveOptions) Complete(args []string, transport string) error {
 	o.transport = transport
+	if err := o.bodyOutput.Complete(); err != nil {
+		return err
+	}
+	tagFilter, err := match.ParseTagFilter(o.tagFilters)
+	if err != nil {
+		return err
+	}
+	o.tagFilter = tagFilter
+	filter, err := match.ParseFilter(o.filter)
+	if err != nil {
+		return err
+	}
+	o.filterExpr = filter
 	if len(args) >= 1 {
 		o.channel = args[0]
-		return nil
+	} else {
+		return fmt.Errorf("missing channel argument")
 	}
-	return fmt.Errorf("missing channel argument")
+	if o.execStr != "" {
+		o.handler = strings.Fields(o.execStr)
+		if len(o.handler) == 0 {
+			return fmt.Errorf("missing handler to run in --exec flag")
+		}
+	}
+	return nil
 }
 
 func (o *CommandsReceiveOptions) Validate() error {
+	if o.handler == nil {
+		if o.bodyOutput.Raw && !o.autoResponse {
+			return fmt.Errorf("--raw requires --auto-response or --exec, commands cannot be confirmed by a prompt")
+		}
+		return nil
+	}
+	if o.autoResponse {
+		return fmt.Errorf("only one of --exec or --auto-response can be set")
+	}
+	if o.execTimeout <= 0 {
+		return fmt.Errorf("exec timeout must be greater than 0")
+	}
+	if o.concurrency <= 0 {
+		return fmt.Errorf("concurrency must be greater than 0")
+	}
 	return nil
 }
 
@@ -79,56 +144,79 @@ func (o *CommandsReceiveOptions) Run(ctx context.Context) error {
 		client.Close()
 	}()
 
-	errChan := make(chan error, 1)
-	commandsChan, err := client.SubscribeToCommands(ctx, o.channel, o.group, errChan)
+	// sem limits the concurrent exec handlers, handlers run with the command context so a resubscribe does not cancel them
+	sem := make(chan struct{}, o.concurrency)
+	wg := sync.WaitGroup{}
+	defer wg.Wait()
+	handlerCtx := ctx
+	return kubemq.Subscribe(ctx, "'commands' subscription", o.reconnect, func(ctx context.Context) error {
+		errChan := make(chan error, 1)
+		commandsChan, err := client.SubscribeToCommands(ctx, o.channel, o.group, errChan)
+		if err != nil {
+			return fmt.Errorf("receive 'commands' messages, %s", err.Error())
+		}
+		for {
+			utils.Println("waiting for the next command body...")
+			select {
+			case err := <-errChan:
+				return fmt.Errorf("server disconnected with error: %s", err.Error())
+			case command, opened := <-commandsChan:
+				if !opened {
+					return fmt.Errorf("server disconnected")
+				}
+				if !o.tagFilter.Match(command.Tags) || !o.filterExpr.Match(newFilterMessage(command)) {
+					continue
+				}
+				printCommandReceive(command)
+				if o.handler != nil {
+					select {
+					case sem <- struct{}{}:
+					case <-ctx.Done():
+						return nil
+					}
+					wg.Add(1)
+					go func() {
+						defer func() {
+							<-sem
+							wg.Done()
+						}()
+						o.runHandler(handlerCtx, client, command)
+					}()
+					continue
+				}
+				if o.autoResponse {
+					err = client.R().SetRequestId(command.Id).SetExecutedAt(time.Now()).SetResponseTo(command.ResponseTo).Send(ctx)
+					if err != nil {
+						return err
+					}
+					utils.Println("auto execution sent executed response ")
+					continue
+				}
+				var isExecuted bool
+				prompt := &survey.Confirm{
+					Renderer: survey.Renderer{},
+					Message:  "Set executed ?",
+					Help:     "",
+				}
+				err := utils.AskOne(prompt, &isExecuted, "--auto-response flag")
 
-	if err != nil {
-		utils.Println(fmt.Errorf("receive commands messages, %s", err.Error()).Error())
-	}
-	for {
-		utils.Println("waiting for the next command body...")
-		select {
-		case err := <-errChan:
-			return fmt.Errorf("server disconnected with error: %s", err.Error())
-		case command, opened := <-commandsChan:
-			if !opened {
-				utils.Println("server disconnected")
-				return nil
-			}
-			printCommandReceive(command)
-			if o.autoResponse {
-				err = client.R().SetRequestId(command.Id).SetExecutedAt(time.Now()).SetResponseTo(command.ResponseTo).Send(ctx)
 				if err != nil {
-					return err
+					return utils.StopReconnect(err)
 				}
-				utils.Println("auto execution sent executed response ")
-				continue
-			}
-			var isExecuted bool
-			prompt := &survey.Confirm{
-				Renderer: survey.Renderer{},
-				Message:  "Set executed ?",
-				Help:     "",
-			}
-			err := survey.AskOne(prompt, &isExecuted)
-
-			if err != nil {
-				return err
-			}
-			if isExecuted {
-				err = client.R().SetRequestId(command.Id).SetExecutedAt(time.Now()).SetResponseTo(command.ResponseTo).Send(ctx)
+				if isExecuted {
+					err = client.R().SetRequestId(command.Id).SetExecutedAt(time.Now()).SetResponseTo(command.ResponseTo).Send(ctx)
+					if err != nil {
+						return err
+					}
+					continue
+				}
+				err = client.R().SetRequestId(command.Id).SetError(fmt.Errorf("commnad not executed")).SetResponseTo(command.ResponseTo).Send(ctx)
 				if err != nil {
 					return err
 				}
-				continue
-			}
-			err = client.R().SetRequestId(command.Id).SetError(fmt.Errorf("commnad not executed")).SetResponseTo(command.ResponseTo).Send(ctx)
-			if err != nil {
-				return err
+			case <-ctx.Done():
+				return nil
 			}
-		case <-ctx.Done():
-			return nil
 		}
-	}
-
+	})
 }
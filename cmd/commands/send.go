@@ -8,20 +8,26 @@ import (
 	"github.com/kubemq-io/kubemqctl/pkg/k8s"
 	"github.com/kubemq-io/kubemqctl/pkg/kubemq"
 	"github.com/kubemq-io/kubemqctl/pkg/targets"
+	"github.com/kubemq-io/kubemqctl/pkg/template"
 	"github.com/kubemq-io/kubemqctl/pkg/utils"
 	"github.com/spf13/cobra"
 	"time"
 )
 
 type CommandsSendOptions struct {
-	cfg       *config.Config
-	transport string
-	channel   string
-	body      string
-	metadata  string
-	timeout   int
-	fileName  bool
-	build     bool
+	cfg           *config.Config
+	transport     string
+	channel       string
+	body          string
+	metadata      string
+	timeout       int
+	fileName      bool
+	build         bool
+	buildMetadata string
+	buildData     string
+	message       template.MessageOptions
+	tmpl          *template.MessageTemplate
+	bodyOptions   utils.BodyOptions
 }
 
 var commandsSendExamples = `
@@ -32,7 +38,16 @@ var commandsSendExamples = `
 	kubemqctl commands send some-channel some-body -m some-metadata
 	
 	# Send command to a 'commands' channel with 120 seconds timeout
-	kubemqctl commands send some-channel some-body -o 120
+	kubemqctl commands send some-channel some-body -t 120
+
+	# Send command to a 'commands' channel with a templated body
+	kubemqctl commands send some-channel --body-template '{"id":"{{ .Uuid }}","at":{{ .Unix }}}'
+
+	# Send command to a 'commands' channel with body loaded from a file
+	kubemqctl commands send some-channel @command.json
+
+	# Send command to a 'commands' channel with tags
+	kubemqctl commands send some-channel some-command --tag route=billing
 `
 var commandsSendLong = `Send command allow to send messages to 'commands' channel with an option to set command time-out`
 var commandsSendShort = `Send messages to 'commands' channel command`
@@ -58,9 +73,13 @@ func NewCmdCommandsSend(ctx context.Context, cfg *config.Config) *cobra.Command
 		},
 	}
 	cmd.PersistentFlags().StringVarP(&o.metadata, "metadata", "m", "", "Set metadata body")
-	cmd.PersistentFlags().IntVarP(&o.timeout, "timeout", "o", 30, "Set command timeout")
-	cmd.PersistentFlags().BoolVarP(&o.fileName, "file", "f", false, "set load body from file")
+	cmd.PersistentFlags().IntVarP(&o.timeout, "timeout", "t", 30, "Set command timeout in seconds, the shorthand is -t as -o is the global output flag")
+	cmd.PersistentFlags().BoolVarP(&o.fileName, "file", "f", false, "set load body from file, file name is set by the body argument")
 	cmd.PersistentFlags().BoolVarP(&o.build, "build", "b", false, "build kubemq targets request")
+	cmd.PersistentFlags().StringVarP(&o.buildMetadata, "build-metadata", "", "", "set kubemq targets request metadata in key1=value1,key2=value2 format")
+	cmd.PersistentFlags().StringVarP(&o.buildData, "build-data", "", "", "set kubemq targets request data file")
+	o.message.AddFlags(cmd)
+	o.bodyOptions.AddFlags(cmd)
 	return cmd
 }
 
@@ -72,8 +91,16 @@ func (o *CommandsSendOptions) Complete(args []string, transport string) error {
 	} else {
 		return fmt.Errorf("missing channel argument")
 	}
+	tmpl, err := o.message.Complete(o.metadata)
+	if err != nil {
+		return err
+	}
+	if tmpl != nil {
+		o.tmpl = tmpl
+		return nil
+	}
 	if o.build {
-		data, err := targets.BuildRequest()
+		data, err := targets.BuildRequest(o.buildMetadata, o.buildData)
 		if err != nil {
 			return err
 		}
@@ -81,17 +108,21 @@ func (o *CommandsSendOptions) Complete(args []string, transport string) error {
 		return nil
 	}
 	if o.fileName {
-		data, err := targets.BuildFile()
+		fileName := ""
+		if len(args) >= 2 {
+			fileName = args[1]
+		}
+		data, err := targets.BuildFile(fileName)
 		if err != nil {
 			return err
 		}
 		o.body = string(data)
 	} else {
-		if len(args) >= 2 {
-			o.body = args[1]
-		} else {
-			return fmt.Errorf("missing body argument")
+		body, err := o.bodyOptions.Body(args[1:])
+		if err != nil {
+			return err
 		}
+		o.body = string(body)
 	}
 	return nil
 }
@@ -110,19 +141,31 @@ func (o *CommandsSendOptions) Run(ctx context.Context) error {
 		client.Close()
 	}()
 
+	m, err := o.render(0)
+	if err != nil {
+		return err
+	}
 	msg := client.C().
 		SetChannel(o.channel).
 		SetId(uuid.New().String()).
-		SetBody([]byte(o.body)).
-		SetMetadata(o.metadata).
+		SetBody(m.Body).
+		SetMetadata(m.Metadata).
+		SetTags(m.Tags).
 		SetTimeout(time.Duration(o.timeout) * time.Second)
-	fmt.Println("Sending Command:")
+	utils.Println("Sending Command:")
 	printCommand(msg)
 	res, err := msg.Send(ctx)
 	if err != nil {
 		return fmt.Errorf("sending commands body, %s", err.Error())
 	}
-	fmt.Println("Getting  Command Response:")
+	utils.Println("Getting  Command Response:")
 	printCommandResponse(res)
 	return nil
 }
+
+func (o *CommandsSendOptions) render(i int) (*template.Message, error) {
+	if o.tmpl == nil {
+		return o.message.Message([]byte(o.body), o.metadata), nil
+	}
+	return o.tmpl.Render(o.channel, i)
+}
@@ -11,20 +11,28 @@ import (
 )
 
 type AccessOptions struct {
-	cfg *config.Config
+	cfg    *config.Config
+	wizard *WizardOptions
 }
 
 var accessExamples = `
 	# Execute access configuration
 	# kubemqctl config access
+
+	# Execute SSL secured access configuration without prompts
+	# kubemqctl config access --secured --cert-file ./cert.pem --client-id my-client --no-input
+
+	# Execute access configuration without access control and without prompts
+	# kubemqctl config access --secured=false --no-input
 `
 var accessLong = `Config access command allows to set Kubemqctl access`
 var accessShort = `Config access command allows to set Kubemqctl access`
 
 // NewCmdCreate returns new initialized instance of create sub command
-func NewCmdAccess(ctx context.Context, cfg *config.Config) *cobra.Command {
+func NewCmdAccess(ctx context.Context, cfg *config.Config, wizard *WizardOptions) *cobra.Command {
 	o := &AccessOptions{
-		cfg: cfg,
+		cfg:    cfg,
+		wizard: wizard,
 	}
 	cmd := &cobra.Command{
 		Use:     "access",
@@ -53,22 +61,41 @@ func (o *AccessOptions) Validate() error {
 }
 
 func (o *AccessOptions) Run(ctx context.Context) error {
-	err := runAccessSelection(o.cfg)
+	err := runAccessSelection(o.cfg, o.wizard)
 	if err != nil {
 		return err
 	}
 	return o.cfg.Save()
 }
 
-func runAccessSelection(cfg *config.Config) error {
+func runAccessSelection(cfg *config.Config, w *WizardOptions) error {
+	if utils.IsNoInput() {
+		if !w.isAccessSet() {
+			return utils.MissingInput("--secured, --cert-file, --client-id or --auth-token-file flag")
+		}
+		cfg.IsSecured = w.secured
+		cfg.CertFile = w.certFile
+		if cfg.IsSecured {
+			if cfg.CertFile == "" {
+				return fmt.Errorf("missing --cert-file flag of SSL secured connection")
+			}
+			_, err := ioutil.ReadFile(cfg.CertFile)
+			if err != nil {
+				return fmt.Errorf("error loading cert file: %s", err.Error())
+			}
+		}
+		cfg.ClientId = w.clientId
+		cfg.AuthTokenFile = w.authTokenFile
+		return nil
+	}
 	isConfig := false
 	promptIsSecured := &survey.Confirm{
 		Renderer: survey.Renderer{},
 		Message:  "Configure access control",
-		Default:  false,
+		Default:  w.isAccessSet(),
 		Help:     "Configure access control",
 	}
-	err := survey.AskOne(promptIsSecured, &isConfig)
+	err := utils.AskOne(promptIsSecured, &isConfig, "--secured flag")
 	if err != nil {
 		return err
 	}
@@ -77,10 +104,10 @@ func runAccessSelection(cfg *config.Config) error {
 		promptIsSecured := &survey.Confirm{
 			Renderer: survey.Renderer{},
 			Message:  "Set SSL Secured connection ?",
-			Default:  false,
+			Default:  w.secured,
 			Help:     "Set Kubemq secured connection",
 		}
-		err := survey.AskOne(promptIsSecured, &cfg.IsSecured)
+		err := utils.AskOne(promptIsSecured, &cfg.IsSecured, "--secured flag")
 		if err != nil {
 			return err
 		}
@@ -88,10 +115,10 @@ func runAccessSelection(cfg *config.Config) error {
 			promptCertFile := &survey.Input{
 				Renderer: survey.Renderer{},
 				Message:  "Set cert file path:",
-				Default:  "",
+				Default:  w.certFile,
 				Help:     "Set Kubemq cert file path",
 			}
-			err = survey.AskOne(promptCertFile, &cfg.CertFile)
+			err = utils.AskOne(promptCertFile, &cfg.CertFile, "--cert-file flag")
 			if err != nil {
 				return err
 			}
@@ -105,20 +132,24 @@ func runAccessSelection(cfg *config.Config) error {
 		promptSetClientIDSecured := &survey.Confirm{
 			Renderer: survey.Renderer{},
 			Message:  "Would you like to set connection ClientId ?",
-			Default:  false,
+			Default:  w.clientId != "",
 		}
-		err = survey.AskOne(promptSetClientIDSecured, &isSetClientId)
+		err = utils.AskOne(promptSetClientIDSecured, &isSetClientId, "--client-id flag")
 		if err != nil {
 			return err
 		}
 		if isSetClientId {
+			clientId := cfg.ClientId
+			if w.clientId != "" {
+				clientId = w.clientId
+			}
 			promptClientId := &survey.Input{
 				Renderer: survey.Renderer{},
 				Message:  "Set ClientId:",
-				Default:  cfg.ClientId,
+				Default:  clientId,
 				Help:     "Set ClientId for every connection",
 			}
-			err = survey.AskOne(promptClientId, &cfg.ClientId)
+			err = utils.AskOne(promptClientId, &cfg.ClientId, "--client-id flag")
 			if err != nil {
 				return err
 			}
@@ -129,20 +160,24 @@ func runAccessSelection(cfg *config.Config) error {
 		promptSetAuthToken := &survey.Confirm{
 			Renderer: survey.Renderer{},
 			Message:  "Would you like to set JWT Authentication token ?",
-			Default:  false,
+			Default:  w.authTokenFile != "",
 		}
-		err = survey.AskOne(promptSetAuthToken, &isAuthToken)
+		err = utils.AskOne(promptSetAuthToken, &isAuthToken, "--auth-token-file flag")
 		if err != nil {
 			return err
 		}
 		if isAuthToken {
+			authTokenFile := cfg.AuthTokenFile
+			if w.authTokenFile != "" {
+				authTokenFile = w.authTokenFile
+			}
 			promptAuthToken := &survey.Editor{
 				Renderer: survey.Renderer{},
 				Message:  "Set JWT Authentication token file",
-				Default:  cfg.AuthTokenFile,
+				Default:  authTokenFile,
 				Help:     "Set JWT Authentication token file",
 			}
-			err = survey.AskOne(promptAuthToken, &cfg.AuthTokenFile)
+			err = utils.AskOne(promptAuthToken, &cfg.AuthTokenFile, "--auth-token-file flag")
 			if err != nil {
 				return err
 			}
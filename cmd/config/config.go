@@ -8,19 +8,24 @@ import (
 )
 
 type ConfigOptions struct {
-	Cfg *config.Config
+	Cfg    *config.Config
+	wizard *WizardOptions
 }
 
 var configExamples = `
 	# Run Kubemqctl configuration wizard
 	# kubemqctl config
+
+	# Run Kubemqctl configuration without prompts
+	# kubemqctl config --connection kubernetes --context my-context --cluster kubemq/kubemq-cluster --secured=false --no-input
 `
 var configLong = `Config command allows to set Kubemqctl configuration with a wizard`
 var configShort = `Run Kubemqctl configuration wizard command`
 
 func NewCmdConfig(ctx context.Context, cfg *config.Config) *cobra.Command {
 	o := &ConfigOptions{
-		Cfg: cfg,
+		Cfg:    cfg,
+		wizard: &WizardOptions{},
 	}
 	cmd := &cobra.Command{
 		Use:     "config",
@@ -36,10 +41,11 @@ func NewCmdConfig(ctx context.Context, cfg *config.Config) *cobra.Command {
 			utils.CheckErr(o.Run(ctx))
 		},
 	}
-	cmd.AddCommand(NewCmdConnection(ctx, cfg))
-	cmd.AddCommand(NewCmdContext(ctx, cfg))
-	cmd.AddCommand(NewCmdAccess(ctx, cfg))
-	cmd.AddCommand(NewCmdLicense(ctx, cfg))
+	o.wizard.addFlags(cmd)
+	cmd.AddCommand(NewCmdConnection(ctx, cfg, o.wizard))
+	cmd.AddCommand(NewCmdContext(ctx, cfg, o.wizard))
+	cmd.AddCommand(NewCmdAccess(ctx, cfg, o.wizard))
+	cmd.AddCommand(NewCmdLicense(ctx, cfg, o.wizard))
 	return cmd
 }
 
@@ -53,18 +59,18 @@ func (o *ConfigOptions) Validate() error {
 }
 
 func (o *ConfigOptions) Run(ctx context.Context) error {
-	if err := runConnectionSelection(o.Cfg); err != nil {
+	if err := runConnectionSelection(o.Cfg, o.wizard); err != nil {
 		return err
 	}
 	if o.Cfg.AutoIntegrated {
-		if err := runContextSelection(o.Cfg); err != nil {
+		if err := runContextSelection(o.Cfg, o.wizard); err != nil {
 			return err
 		}
 	}
-	if err := runAccessSelection(o.Cfg); err != nil {
+	if err := runAccessSelection(o.Cfg, o.wizard); err != nil {
 		return err
 	}
-	if err := runLicenseSelection(o.Cfg); err != nil {
+	if err := runLicenseSelection(o.Cfg, o.wizard); err != nil {
 		return err
 	}
 	err := o.Cfg.Save()
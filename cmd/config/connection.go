@@ -2,26 +2,33 @@ package config
 
 import (
 	"context"
+	"fmt"
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/kubemq-io/kubemqctl/pkg/config"
 	"github.com/kubemq-io/kubemqctl/pkg/utils"
 	"github.com/spf13/cobra"
+	"strconv"
 )
 
 type ConnectionOptions struct {
-	cfg *config.Config
+	cfg    *config.Config
+	wizard *WizardOptions
 }
 
 var connectionExamples = `
 	# Execute connection configuration
 	# kubemqctl config connection
+
+	# Execute direct connection configuration without prompts
+	# kubemqctl config connection --connection direct --host kubemq.local --no-input
 `
 var connectionLong = `Config connection command allows to set Kubemqctl connection`
 var connectionShort = `Config connection command allows to set Kubemqctl connection`
 
-func NewCmdConnection(ctx context.Context, cfg *config.Config) *cobra.Command {
+func NewCmdConnection(ctx context.Context, cfg *config.Config, wizard *WizardOptions) *cobra.Command {
 	o := &ConnectionOptions{
-		cfg: cfg,
+		cfg:    cfg,
+		wizard: wizard,
 	}
 	cmd := &cobra.Command{
 		Use:     "connection",
@@ -50,80 +57,103 @@ func (o *ConnectionOptions) Validate() error {
 }
 
 func (o *ConnectionOptions) Run(ctx context.Context) error {
-	err := runConnectionSelection(o.cfg)
+	err := runConnectionSelection(o.cfg, o.wizard)
 	if err != nil {
 		return err
 	}
 	return o.cfg.Save()
 }
 
-func runConnectionSelection(cfg *config.Config) error {
-	integrationType := ""
-	integrationSelect := &survey.Select{
-		Renderer: survey.Renderer{},
-		Message:  "Select Connection Type:",
-		Options: []string{
-			"Kubernetes cluster",
-			"Direct"},
-		Default: "Kubernetes cluster",
-		Help:    "Select the location of Kubemq server",
-	}
-	err := survey.AskOne(integrationSelect, &integrationType)
+func runConnectionSelection(cfg *config.Config, w *WizardOptions) error {
+	integrationType, err := w.connectionOption()
 	if err != nil {
 		return err
 	}
+	if !utils.IsNoInput() {
+		integrationSelect := &survey.Select{
+			Renderer: survey.Renderer{},
+			Message:  "Select Connection Type:",
+			Options: []string{
+				"Kubernetes cluster",
+				"Direct"},
+			Default: integrationType,
+			Help:    "Select the location of Kubemq server",
+		}
+		err := utils.AskOne(integrationSelect, &integrationType, "--connection flag")
+		if err != nil {
+			return err
+		}
+	}
 	switch integrationType {
 	case "Kubernetes cluster":
 		cfg.AutoIntegrated = true
+		cfg.KubeConfigPath = w.kubeConfig
+		if utils.IsNoInput() {
+			return nil
+		}
 		prompt := &survey.Input{
 			Renderer: survey.Renderer{},
 			Message:  "Select kube config path (press Enter for default):",
-			Default:  "",
+			Default:  w.kubeConfig,
 			Help:     "Set kube.config file path if not kubectl default",
 		}
-		err := survey.AskOne(prompt, &cfg.KubeConfigPath)
+		err := utils.AskOne(prompt, &cfg.KubeConfigPath, "--kube-config flag")
 		if err != nil {
 			return err
 		}
 	case "Direct":
 		cfg.AutoIntegrated = false
+		cfg.Host = w.host
+		cfg.GrpcPort = w.grpcPort
+		cfg.RestPort = w.restPort
+		cfg.ApiPort = w.apiPort
+		cfg.ConnectionType = w.transport
+		if utils.IsNoInput() {
+			if w.host == "" {
+				return fmt.Errorf("missing --host flag of direct connection")
+			}
+			if w.transport != "grpc" && w.transport != "rest" {
+				return fmt.Errorf("invalid --transport flag value %s, must be grpc or rest", w.transport)
+			}
+			return nil
+		}
 		promptHost := &survey.Input{
 			Renderer: survey.Renderer{},
 			Message:  "Set Kubemq Host:",
-			Default:  "localhost",
+			Default:  w.host,
 			Help:     "Set Kubemq host",
 		}
-		err = survey.AskOne(promptHost, &cfg.Host, survey.WithValidator(survey.MinLength(1)))
+		err = utils.AskOne(promptHost, &cfg.Host, "--host flag", survey.WithValidator(survey.MinLength(1)))
 		if err != nil {
 			return err
 		}
 		promptGrpcPort := &survey.Input{
 			Renderer: survey.Renderer{},
 			Message:  "Set Kubemq gRPC port interface:",
-			Default:  "50000",
+			Default:  strconv.Itoa(w.grpcPort),
 			Help:     "Set Kubemq gRPC port",
 		}
-		err = survey.AskOne(promptGrpcPort, &cfg.GrpcPort)
+		err = utils.AskOne(promptGrpcPort, &cfg.GrpcPort, "--grpc-port flag")
 		if err != nil {
 			return err
 		}
 		promptRestPort := &survey.Input{
 			Renderer: survey.Renderer{},
 			Message:  "Set Kubemq Rest port interface:",
-			Default:  "9090",
+			Default:  strconv.Itoa(w.restPort),
 			Help:     "Set Kubemq Rest port",
 		}
-		err = survey.AskOne(promptRestPort, &cfg.RestPort)
+		err = utils.AskOne(promptRestPort, &cfg.RestPort, "--rest-port flag")
 		if err != nil {
 			return err
 		}
 		promptAPIPort := &survey.Input{
 			Renderer: survey.Renderer{},
 			Message:  "Set Kubemq Api port interface:",
-			Default:  "8080",
+			Default:  strconv.Itoa(w.apiPort),
 			Help:     "Set Kubemq Api port",
 		}
-		err = survey.AskOne(promptAPIPort, &cfg.ApiPort)
+		err = utils.AskOne(promptAPIPort, &cfg.ApiPort, "--api-port flag")
 		if err != nil {
 			return err
 		}
@@ -131,10 +161,10 @@ func runConnectionSelection(cfg *config.Config) error {
 			Renderer: survey.Renderer{},
 			Message:  "Set default interface:",
 			Options:  []string{"grpc", "rest"},
-			Default:  "grpc",
+			Default:  w.transport,
 			Help:     "Select the default interface connection type",
 		}
-		err = survey.AskOne(connectionTypeSelect, &cfg.ConnectionType)
+		err = utils.AskOne(connectionTypeSelect, &cfg.ConnectionType, "--transport flag")
 		if err != nil {
 			return err
 		}
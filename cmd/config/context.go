@@ -2,6 +2,7 @@ package config
 
 import (
 	"context"
+	"fmt"
 	"github.com/kubemq-io/kubemqctl/pkg/k8s/manager/cluster"
 
 	"github.com/AlecAivazis/survey/v2"
@@ -14,20 +15,25 @@ import (
 )
 
 type ContextOptions struct {
-	cfg *config.Config
+	cfg    *config.Config
+	wizard *WizardOptions
 }
 
 var contextExamples = `
 	# Execute context configuration
 	# kubemqctl config context
+
+	# Execute context configuration without prompts
+	# kubemqctl config context --context my-context --cluster kubemq/kubemq-cluster --no-input
 `
 var contextLong = `Config context command allows to set Kubemqctl context`
 var contextShort = `Config context command allows to set Kubemqctl context`
 
 // NewCmdCreate returns new initialized instance of create sub command
-func NewCmdContext(ctx context.Context, cfg *config.Config) *cobra.Command {
+func NewCmdContext(ctx context.Context, cfg *config.Config, wizard *WizardOptions) *cobra.Command {
 	o := &ContextOptions{
-		cfg: cfg,
+		cfg:    cfg,
+		wizard: wizard,
 	}
 	cmd := &cobra.Command{
 		Use:     "context",
@@ -56,14 +62,14 @@ func (o *ContextOptions) Validate() error {
 }
 
 func (o *ContextOptions) Run(ctx context.Context) error {
-	err := runContextSelection(o.cfg)
+	err := runContextSelection(o.cfg, o.wizard)
 	if err != nil {
 		return err
 	}
 	return o.cfg.Save()
 }
 
-func runContextSelection(cfg *config.Config) error {
+func runContextSelection(cfg *config.Config, w *WizardOptions) error {
 	c, err := client.NewClient(cfg.KubeConfigPath)
 	if err != nil {
 		return err
@@ -77,21 +83,29 @@ func runContextSelection(cfg *config.Config) error {
 		list = append(list, key)
 	}
 	sort.Strings(list)
-	contextSelected := ""
-	contextSelect := &survey.Select{
-		Renderer:      survey.Renderer{},
-		Message:       "Select kubernetes cluster context:",
-		Options:       list,
-		Default:       current,
-		Help:          "Set kubernetes connection context",
-		PageSize:      0,
-		VimMode:       false,
-		FilterMessage: "",
-		Filter:        nil,
-	}
-	err = survey.AskOne(contextSelect, &contextSelected)
-	if err != nil {
-		return err
+	contextSelected := w.kubeContext
+	if contextSelected != "" {
+		if _, ok := contextMap[contextSelected]; !ok {
+			return fmt.Errorf("kubernetes cluster context %s not found", contextSelected)
+		}
+	} else if utils.IsNoInput() {
+		contextSelected = current
+	} else {
+		contextSelect := &survey.Select{
+			Renderer:      survey.Renderer{},
+			Message:       "Select kubernetes cluster context:",
+			Options:       list,
+			Default:       current,
+			Help:          "Set kubernetes connection context",
+			PageSize:      0,
+			VimMode:       false,
+			FilterMessage: "",
+			Filter:        nil,
+		}
+		err = utils.AskOne(contextSelect, &contextSelected, "--context flag")
+		if err != nil {
+			return err
+		}
 	}
 	err = c.SwitchContext(contextSelected)
 	if err != nil {
@@ -105,15 +119,7 @@ func runContextSelection(cfg *config.Config) error {
 	if len(list) == 0 {
 		utils.Println("No Kubemq clusters were found for selection")
 	} else {
-		clusterSelected := ""
-		clusterSelect := &survey.Select{
-			Renderer: survey.Renderer{},
-			Message:  "Select current Kubemq cluster:",
-			Options:  list,
-			Default:  list[0],
-			Help:     "Select the default Kubemq cluster from available Kubemq clusters",
-		}
-		err := survey.AskOne(clusterSelect, &clusterSelected)
+		clusterSelected, err := utils.SelectOne(w.cluster, list, "Select current Kubemq cluster:", "--cluster flag")
 		if err != nil {
 			return err
 		}
@@ -9,20 +9,25 @@ import (
 )
 
 type LicenseOptions struct {
-	cfg *config.Config
+	cfg    *config.Config
+	wizard *WizardOptions
 }
 
 var licenseExamples = `
 	# Execute license configuration
 	# kubemqctl config license
+
+	# Execute license configuration without prompts
+	# kubemqctl config license --license-key my-license-key --no-input
 `
 var licenseLong = `Config license command allows to set Kubemqctl license`
 var licenseShort = `Config license command allows to set Kubemqctl license`
 
 // NewCmdCreate returns new initialized instance of create sub command
-func NewCmdLicense(ctx context.Context, cfg *config.Config) *cobra.Command {
+func NewCmdLicense(ctx context.Context, cfg *config.Config, wizard *WizardOptions) *cobra.Command {
 	o := &LicenseOptions{
-		cfg: cfg,
+		cfg:    cfg,
+		wizard: wizard,
 	}
 	cmd := &cobra.Command{
 		Use:     "license",
@@ -51,21 +56,34 @@ func (o *LicenseOptions) Validate() error {
 }
 
 func (o *LicenseOptions) Run(ctx context.Context) error {
-	err := runLicenseSelection(o.cfg)
+	err := runLicenseSelection(o.cfg, o.wizard)
 	if err != nil {
 		return err
 	}
 	return o.cfg.Save()
 }
 
-func runLicenseSelection(cfg *config.Config) error {
+func runLicenseSelection(cfg *config.Config, w *WizardOptions) error {
+	if w.licenseKey != "" {
+		cfg.LicenseKey = w.licenseKey
+	}
+	if w.licenseFile != "" {
+		data, err := w.licenseData()
+		if err != nil {
+			return err
+		}
+		cfg.LicenseData = data
+	}
+	if utils.IsNoInput() || w.isLicenseSet() {
+		return nil
+	}
 	isLicenseData := false
 	promptSetLicenseData := &survey.Confirm{
 		Renderer: survey.Renderer{},
 		Message:  "Would you like to set license information?:",
 		Default:  false,
 	}
-	err := survey.AskOne(promptSetLicenseData, &isLicenseData)
+	err := utils.AskOne(promptSetLicenseData, &isLicenseData, "--license-key or --license-file flag")
 	if err != nil {
 		return err
 	}
@@ -81,7 +99,7 @@ func runLicenseSelection(cfg *config.Config) error {
 			Default: "License Key",
 			Help:    "Select license type input",
 		}
-		err := survey.AskOne(dataTypePrompt, &dataType)
+		err := utils.AskOne(dataTypePrompt, &dataType, "--license-key or --license-file flag")
 		if err != nil {
 			return err
 		}
@@ -94,7 +112,7 @@ func runLicenseSelection(cfg *config.Config) error {
 				Default:  "",
 				Help:     "Set License Key:",
 			}
-			err := survey.AskOne(prompt, &cfg.LicenseKey)
+			err := utils.AskOne(prompt, &cfg.LicenseKey, "--license-key flag")
 			if err != nil {
 				return err
 			}
@@ -105,7 +123,7 @@ func runLicenseSelection(cfg *config.Config) error {
 				Default:  "",
 				Help:     "Copy & Paste License Data:",
 			}
-			err := survey.AskOne(prompt, &cfg.LicenseData)
+			err := utils.AskOne(prompt, &cfg.LicenseData, "--license-file flag")
 			if err != nil {
 				return err
 			}
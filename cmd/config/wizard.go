@@ -0,0 +1,91 @@
+package config
+
+import (
+	"fmt"
+	"github.com/spf13/cobra"
+	"io/ioutil"
+)
+
+const (
+	connectionKubernetes = "kubernetes"
+	connectionDirect     = "direct"
+)
+
+// WizardOptions holds the configuration values set by flags, the values are the wizard prompts defaults and replace the prompts in --no-input mode
+type WizardOptions struct {
+	connection    string
+	kubeConfig    string
+	host          string
+	grpcPort      int
+	restPort      int
+	apiPort       int
+	transport     string
+	kubeContext   string
+	cluster       string
+	secured       bool
+	certFile      string
+	clientId      string
+	authTokenFile string
+	licenseKey    string
+	licenseFile   string
+	cmd           *cobra.Command
+}
+
+func (w *WizardOptions) addFlags(cmd *cobra.Command) {
+	w.cmd = cmd
+	cmd.PersistentFlags().StringVarP(&w.connection, "connection", "", connectionKubernetes, "set connection type, kubernetes or direct")
+	cmd.PersistentFlags().StringVarP(&w.kubeConfig, "kube-config", "", "", "set kube config file path of kubernetes connection")
+	cmd.PersistentFlags().StringVarP(&w.host, "host", "", "localhost", "set Kubemq host of direct connection")
+	cmd.PersistentFlags().IntVarP(&w.grpcPort, "grpc-port", "", 50000, "set Kubemq gRPC port of direct connection")
+	cmd.PersistentFlags().IntVarP(&w.restPort, "rest-port", "", 9090, "set Kubemq rest port of direct connection")
+	cmd.PersistentFlags().IntVarP(&w.apiPort, "api-port", "", 8080, "set Kubemq api port of direct connection")
+	cmd.PersistentFlags().StringVarP(&w.transport, "transport", "", "grpc", "set default interface of direct connection, grpc or rest")
+	cmd.PersistentFlags().StringVarP(&w.kubeContext, "context", "", "", "set kubernetes cluster context")
+	cmd.PersistentFlags().StringVarP(&w.cluster, "cluster", "", "", "set current Kubemq cluster, i.e. namespace/name")
+	cmd.PersistentFlags().BoolVarP(&w.secured, "secured", "", false, "set SSL secured connection")
+	cmd.PersistentFlags().StringVarP(&w.certFile, "cert-file", "", "", "set cert file path of SSL secured connection")
+	cmd.PersistentFlags().StringVarP(&w.clientId, "client-id", "", "", "set ClientId for every connection")
+	cmd.PersistentFlags().StringVarP(&w.authTokenFile, "auth-token-file", "", "", "set JWT authentication token file path")
+	cmd.PersistentFlags().StringVarP(&w.licenseKey, "license-key", "", "", "set license key")
+	cmd.PersistentFlags().StringVarP(&w.licenseFile, "license-file", "", "", "set license data file path")
+}
+
+func (w *WizardOptions) connectionOption() (string, error) {
+	switch w.connection {
+	case connectionKubernetes:
+		return "Kubernetes cluster", nil
+	case connectionDirect:
+		return "Direct", nil
+	default:
+		return "", fmt.Errorf("invalid connection type %s, must be kubernetes or direct", w.connection)
+	}
+}
+
+// isAccessSet returns true when any access flag is set, --secured=false sets a connection without access control
+func (w *WizardOptions) isAccessSet() bool {
+	return w.changed("secured", "cert-file", "client-id", "auth-token-file")
+}
+
+func (w *WizardOptions) changed(names ...string) bool {
+	if w.cmd == nil {
+		return false
+	}
+	for _, name := range names {
+		if w.cmd.PersistentFlags().Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *WizardOptions) isLicenseSet() bool {
+	return w.licenseKey != "" || w.licenseFile != ""
+}
+
+func (w *WizardOptions) licenseData() (string, error) {
+	data, err := ioutil.ReadFile(w.licenseFile)
+	if err != nil {
+		return "", fmt.Errorf("error loading license file: %s", err.Error())
+	}
+	return string(data), nil
+}
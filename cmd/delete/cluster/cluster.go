@@ -3,7 +3,6 @@ package cluster
 import (
 	"context"
 	"fmt"
-	"github.com/AlecAivazis/survey/v2"
 	"github.com/kubemq-io/kubemqctl/pkg/config"
 	client2 "github.com/kubemq-io/kubemqctl/pkg/k8s/client"
 	"github.com/kubemq-io/kubemqctl/pkg/k8s/manager/cluster"
@@ -14,12 +13,17 @@ import (
 )
 
 type DeleteOptions struct {
-	cfg *config.Config
+	cfg      *config.Config
+	clusters []string
+	yes      bool
 }
 
 var deleteExamples = `
  	# Delete Kubemq cluster
 	kubemqctl delete cluster
+
+	# Delete Kubemq cluster kubemq/kubemq-cluster without prompts
+	kubemqctl delete cluster --cluster kubemq/kubemq-cluster -y
 `
 var deleteLong = `Delete one or more Kubemq clusters`
 var deleteShort = `Delete Kubemq cluster`
@@ -43,7 +47,8 @@ func NewCmdDelete(ctx context.Context, cfg *config.Config) *cobra.Command {
 			utils.CheckErr(o.Run(ctx))
 		},
 	}
-
+	cmd.PersistentFlags().StringArrayVarP(&o.clusters, "cluster", "", []string{}, "set Kubemq cluster to delete, i.e. namespace/name, can be set multiple times")
+	cmd.PersistentFlags().BoolVarP(&o.yes, "yes", "y", false, "set to confirm deletion without a prompt")
 	return cmd
 }
 
@@ -77,31 +82,12 @@ func (o *DeleteOptions) Run(ctx context.Context) error {
 		return fmt.Errorf("no Kubemq clusters were found to delete")
 	}
 
-	selection := []string{}
-	multiSelected := &survey.MultiSelect{
-		Renderer:      survey.Renderer{},
-		Message:       "Select Kubemq clusters to delete",
-		Options:       clusters.List(),
-		Default:       nil,
-		Help:          "Select Kubemq clusters to delete",
-		PageSize:      0,
-		VimMode:       false,
-		FilterMessage: "",
-		Filter:        nil,
-	}
-	err = survey.AskOne(multiSelected, &selection)
+	selection, err := utils.SelectMany(o.clusters, clusters.List(), "Select Kubemq clusters to delete", "--cluster flag")
 	if err != nil {
 		return err
 	}
 
-	areYouSure := false
-	promptConfirm := &survey.Confirm{
-		Renderer: survey.Renderer{},
-		Message:  "Are you sure ?",
-		Default:  false,
-		Help:     "Confirm Kubemq cluster deletion",
-	}
-	err = survey.AskOne(promptConfirm, &areYouSure)
+	areYouSure, err := utils.Confirm(o.yes, "Confirm Kubemq cluster deletion")
 	if err != nil {
 		return err
 	}
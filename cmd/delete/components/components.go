@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/AlecAivazis/survey/v2"
 	"github.com/kubemq-io/kubemqctl/pkg/config"
 	client2 "github.com/kubemq-io/kubemqctl/pkg/k8s/client"
 	"github.com/kubemq-io/kubemqctl/pkg/k8s/manager/cluster"
@@ -17,12 +16,19 @@ import (
 )
 
 type DeleteOptions struct {
-	cfg *config.Config
+	cfg        *config.Config
+	clusters   []string
+	connectors []string
+	namespaces []string
+	yes        bool
 }
 
 var deleteExamples = `
  	# Delete components
 	kubemqctl delete components
+
+	# Delete Kubemq cluster and its operator without prompts
+	kubemqctl delete components --cluster kubemq/kubemq-cluster --namespace kubemq -y --no-input
 `
 
 var (
@@ -48,7 +54,10 @@ func NewCmdDelete(ctx context.Context, cfg *config.Config) *cobra.Command {
 			utils.CheckErr(o.Run(ctx))
 		},
 	}
-
+	cmd.PersistentFlags().StringArrayVarP(&o.clusters, "cluster", "", []string{}, "set Kubemq cluster to delete, i.e. namespace/name, can be set multiple times")
+	cmd.PersistentFlags().StringArrayVarP(&o.connectors, "connector", "", []string{}, "set Kubemq connector to delete, i.e. namespace/name, can be set multiple times")
+	cmd.PersistentFlags().StringArrayVarP(&o.namespaces, "namespace", "", []string{}, "set namespace of Kubemq operator to delete, can be set multiple times")
+	cmd.PersistentFlags().BoolVarP(&o.yes, "yes", "y", false, "set to confirm deletion without a prompt")
 	return cmd
 }
 
@@ -57,6 +66,10 @@ func (o *DeleteOptions) Complete(args []string) error {
 }
 
 func (o *DeleteOptions) Validate() error {
+	// in --no-input mode only the components set by flags are deleted, at least one must be set
+	if utils.IsNoInput() && len(o.clusters) == 0 && len(o.connectors) == 0 && len(o.namespaces) == 0 {
+		return utils.MissingInput("--cluster, --connector or --namespace flag")
+	}
 	return nil
 }
 
@@ -77,32 +90,17 @@ func (o *DeleteOptions) runCluster(ctx context.Context, client *client2.Client)
 	if len(clusters.List()) == 0 {
 		return nil
 	}
-
-	selection := []string{}
-	multiSelected := &survey.MultiSelect{
-		Renderer:      survey.Renderer{},
-		Message:       "Select Kubemq clusters to delete",
-		Options:       clusters.List(),
-		Default:       nil,
-		Help:          "Select Kubemq clusters to delete",
-		PageSize:      0,
-		VimMode:       false,
-		FilterMessage: "",
-		Filter:        nil,
+	// in --no-input mode only the components set by flags are deleted
+	if utils.IsNoInput() && len(o.clusters) == 0 {
+		return nil
 	}
-	err = survey.AskOne(multiSelected, &selection)
+
+	selection, err := utils.SelectMany(o.clusters, clusters.List(), "Select Kubemq clusters to delete", "--cluster flag")
 	if err != nil {
 		return err
 	}
 
-	areYouSure := false
-	promptConfirm := &survey.Confirm{
-		Renderer: survey.Renderer{},
-		Message:  "Are you sure ?",
-		Default:  false,
-		Help:     "Confirm Kubemq cluster deletion",
-	}
-	err = survey.AskOne(promptConfirm, &areYouSure)
+	areYouSure, err := utils.Confirm(o.yes, "Confirm Kubemq cluster deletion")
 	if err != nil {
 		return err
 	}
@@ -150,32 +148,17 @@ func (o *DeleteOptions) runConnector(ctx context.Context, client *client2.Client
 	if len(connectors.List()) == 0 {
 		return nil
 	}
-
-	selection := []string{}
-	multiSelected := &survey.MultiSelect{
-		Renderer:      survey.Renderer{},
-		Message:       "Select Kubemq connectors to delete",
-		Options:       connectors.List(),
-		Default:       nil,
-		Help:          "Select Kubemq connectors to delete",
-		PageSize:      0,
-		VimMode:       false,
-		FilterMessage: "",
-		Filter:        nil,
+	// in --no-input mode only the components set by flags are deleted
+	if utils.IsNoInput() && len(o.connectors) == 0 {
+		return nil
 	}
-	err = survey.AskOne(multiSelected, &selection)
+
+	selection, err := utils.SelectMany(o.connectors, connectors.List(), "Select Kubemq connectors to delete", "--connector flag")
 	if err != nil {
 		return err
 	}
 
-	areYouSure := false
-	promptConfirm := &survey.Confirm{
-		Renderer: survey.Renderer{},
-		Message:  "Are you sure ?",
-		Default:  false,
-		Help:     "Confirm Kubemq connector deletion",
-	}
-	err = survey.AskOne(promptConfirm, &areYouSure)
+	areYouSure, err := utils.Confirm(o.yes, "Confirm Kubemq connector deletion")
 	if err != nil {
 		return err
 	}
@@ -217,6 +200,9 @@ func (o *DeleteOptions) runOperator(ctx context.Context, client *client2.Client)
 		return nil
 	}
 
+	if utils.IsNoInput() && len(o.namespaces) == 0 {
+		return nil
+	}
 	if len(operators) > 0 {
 		var opList []string
 		opMap := map[string]*appsv1.Deployment{}
@@ -225,31 +211,12 @@ func (o *DeleteOptions) runOperator(ctx context.Context, client *client2.Client)
 			opMap[deployment.Namespace] = deployment
 		}
 
-		selection := []string{}
-		multiSelected := &survey.MultiSelect{
-			Renderer:      survey.Renderer{},
-			Message:       "Select Kubemq operator namespace to delete",
-			Options:       opList,
-			Default:       nil,
-			Help:          "Select Kubemq operator namespace to delete",
-			PageSize:      0,
-			VimMode:       false,
-			FilterMessage: "",
-			Filter:        nil,
-		}
-		err = survey.AskOne(multiSelected, &selection)
+		selection, err := utils.SelectMany(o.namespaces, opList, "Select Kubemq operator namespace to delete", "--namespace flag")
 		if err != nil {
 			return err
 		}
 
-		areYouSure := false
-		promptConfirm := &survey.Confirm{
-			Renderer: survey.Renderer{},
-			Message:  "Are you sure ?",
-			Default:  false,
-			Help:     "Confirm Kubemq operator deletion",
-		}
-		err = survey.AskOne(promptConfirm, &areYouSure)
+		areYouSure, err := utils.Confirm(o.yes, "Confirm Kubemq operator deletion")
 		if err != nil {
 			return err
 		}
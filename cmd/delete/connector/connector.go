@@ -3,7 +3,6 @@ package connector
 import (
 	"context"
 	"fmt"
-	"github.com/AlecAivazis/survey/v2"
 	"github.com/kubemq-io/kubemqctl/pkg/config"
 	client2 "github.com/kubemq-io/kubemqctl/pkg/k8s/client"
 	"github.com/kubemq-io/kubemqctl/pkg/k8s/manager/connector"
@@ -14,12 +13,17 @@ import (
 )
 
 type DeleteOptions struct {
-	cfg *config.Config
+	cfg        *config.Config
+	connectors []string
+	yes        bool
 }
 
 var deleteExamples = `
  	# Delete Kubemq connector
 	kubemqctl delete connector
+
+	# Delete Kubemq connector kubemq/kubemq-connector without prompts
+	kubemqctl delete connector --connector kubemq/kubemq-connector -y
 `
 var deleteLong = `Delete one or more Kubemq connectors`
 var deleteShort = `Delete Kubemq connector`
@@ -43,7 +47,8 @@ func NewCmdDelete(ctx context.Context, cfg *config.Config) *cobra.Command {
 			utils.CheckErr(o.Run(ctx))
 		},
 	}
-
+	cmd.PersistentFlags().StringArrayVarP(&o.connectors, "connector", "", []string{}, "set Kubemq connector to delete, i.e. namespace/name, can be set multiple times")
+	cmd.PersistentFlags().BoolVarP(&o.yes, "yes", "y", false, "set to confirm deletion without a prompt")
 	return cmd
 }
 
@@ -78,31 +83,12 @@ func (o *DeleteOptions) Run(ctx context.Context) error {
 		return fmt.Errorf("no Kubemq connectors were found to delete")
 	}
 
-	selection := []string{}
-	multiSelected := &survey.MultiSelect{
-		Renderer:      survey.Renderer{},
-		Message:       "Select Kubemq connectors to delete",
-		Options:       connectors.List(),
-		Default:       nil,
-		Help:          "Select Kubemq connectors to delete",
-		PageSize:      0,
-		VimMode:       false,
-		FilterMessage: "",
-		Filter:        nil,
-	}
-	err = survey.AskOne(multiSelected, &selection)
+	selection, err := utils.SelectMany(o.connectors, connectors.List(), "Select Kubemq connectors to delete", "--connector flag")
 	if err != nil {
 		return err
 	}
 
-	areYouSure := false
-	promptConfirm := &survey.Confirm{
-		Renderer: survey.Renderer{},
-		Message:  "Are you sure ?",
-		Default:  false,
-		Help:     "Confirm Kubemq connector deletion",
-	}
-	err = survey.AskOne(promptConfirm, &areYouSure)
+	areYouSure, err := utils.Confirm(o.yes, "Confirm Kubemq connector deletion")
 	if err != nil {
 		return err
 	}
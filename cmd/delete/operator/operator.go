@@ -3,7 +3,6 @@ package operator
 import (
 	"context"
 	"fmt"
-	"github.com/AlecAivazis/survey/v2"
 	"github.com/kubemq-io/kubemqctl/pkg/config"
 	"github.com/kubemq-io/kubemqctl/pkg/k8s/client"
 	appsv1 "k8s.io/api/apps/v1"
@@ -14,13 +13,18 @@ import (
 )
 
 type DeleteOptions struct {
-	cfg   *config.Config
-	isAll bool
+	cfg        *config.Config
+	isAll      bool
+	namespaces []string
+	yes        bool
 }
 
 var deleteExamples = `
 	# Delete Kubemq operator 
 	kubemqctl delete operator  
+
+	# Delete Kubemq operator of kubemq namespace without prompts
+	kubemqctl delete operator --namespace kubemq -y
 `
 var deleteLong = `Delete one or more Kubemq operators`
 var deleteShort = `Delete Kubemq operator`
@@ -45,6 +49,8 @@ func NewCmdDelete(ctx context.Context, cfg *config.Config) *cobra.Command {
 		},
 	}
 	cmd.PersistentFlags().BoolVarP(&o.isAll, "remove-all", "", false, "remove all operator components")
+	cmd.PersistentFlags().StringArrayVarP(&o.namespaces, "namespace", "", []string{}, "set namespace of Kubemq operator to delete, can be set multiple times")
+	cmd.PersistentFlags().BoolVarP(&o.yes, "yes", "y", false, "set to confirm deletion without a prompt")
 	return cmd
 }
 
@@ -80,31 +86,12 @@ func (o *DeleteOptions) Run(ctx context.Context) error {
 			opMap[deployment.Namespace] = deployment
 		}
 
-		selection := []string{}
-		multiSelected := &survey.MultiSelect{
-			Renderer:      survey.Renderer{},
-			Message:       "Select Kubemq operator namespace to delete",
-			Options:       opList,
-			Default:       nil,
-			Help:          "Select Kubemq operator namespace to delete",
-			PageSize:      0,
-			VimMode:       false,
-			FilterMessage: "",
-			Filter:        nil,
-		}
-		err = survey.AskOne(multiSelected, &selection)
+		selection, err := utils.SelectMany(o.namespaces, opList, "Select Kubemq operator namespace to delete", "--namespace flag")
 		if err != nil {
 			return err
 		}
 
-		areYouSure := false
-		promptConfirm := &survey.Confirm{
-			Renderer: survey.Renderer{},
-			Message:  "Are you sure ?",
-			Default:  false,
-			Help:     "Confirm Kubemq operator deletion",
-		}
-		err = survey.AskOne(promptConfirm, &areYouSure)
+		areYouSure, err := utils.Confirm(o.yes, "Confirm Kubemq operator deletion")
 		if err != nil {
 			return err
 		}
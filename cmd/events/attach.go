@@ -6,15 +6,20 @@ import (
 	"github.com/kubemq-io/kubemqctl/pkg/attach"
 	"github.com/kubemq-io/kubemqctl/pkg/config"
 	"github.com/kubemq-io/kubemqctl/pkg/k8s"
+	"github.com/kubemq-io/kubemqctl/pkg/match"
+	"github.com/kubemq-io/kubemqctl/pkg/output"
 	"github.com/kubemq-io/kubemqctl/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
 type EventsAttachOptions struct {
-	cfg       *config.Config
-	include   []string
-	exclude   []string
-	resources []string
+	cfg        *config.Config
+	include    []string
+	exclude    []string
+	resources  []string
+	bodyOutput output.BodyOptions
+	filter     string
+	filterExpr *match.Filter
 }
 
 var eventsAttachExamples = `
@@ -32,6 +37,9 @@ var eventsAttachExamples = `
 
 	# attach to some-events 'events' channel and output running messages filter by exclude regex (not-some*)
 	kubemqctl events attach some-events -e not-some*
+
+	# attach to some-events 'events' channel and output running messages filter by a field of the attached message json
+	kubemqctl events attach some-events --filter 'body.Metadata == "orders"'
 `
 var eventsAttachLong = `Attach command allows to display 'events' channel content for debugging proposes`
 var eventsAttachShort = `Attach to 'events' channels command`
@@ -58,10 +66,20 @@ func NewCmdEventsAttach(ctx context.Context, cfg *config.Config) *cobra.Command
 	}
 	cmd.PersistentFlags().StringArrayVarP(&o.include, "include", "i", []string{}, "set (regex) strings to include")
 	cmd.PersistentFlags().StringArrayVarP(&o.exclude, "exclude", "e", []string{}, "set (regex) strings to exclude")
+	cmd.PersistentFlags().StringVarP(&o.filter, "filter", "", "", "set filter expression over body json paths, metadata, tags, channel, client_id and id, i.e. body.order.total > 100 && tags.region == \"eu\", body paths select fields of the attached message json, messages which do not match are not printed")
+	o.bodyOutput.AddFlags(cmd)
 	return cmd
 }
 
 func (o *EventsAttachOptions) Complete(args []string, transport string) error {
+	if err := o.bodyOutput.Complete(); err != nil {
+		return err
+	}
+	filter, err := match.ParseFilter(o.filter)
+	if err != nil {
+		return err
+	}
+	o.filterExpr = filter
 	if len(args) == 0 {
 		return fmt.Errorf("missing channel argument")
 
@@ -80,7 +98,7 @@ func (o *EventsAttachOptions) Validate() error {
 }
 
 func (o *EventsAttachOptions) Run(ctx context.Context) error {
-	err := attach.Run(ctx, o.cfg, o.resources, o.include, o.exclude)
+	err := attach.Run(ctx, o.cfg, o.resources, o.include, o.exclude, o.filterExpr)
 	if err != nil {
 		return err
 	}
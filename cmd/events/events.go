@@ -17,6 +17,12 @@ var eventsExamples = `
 	# Execute attach to an 'events' command
 	kubemqctl events attach
 
+	# Execute record 'events' command
+	kubemqctl events record
+
+	# Execute replay 'events' command
+	kubemqctl events replay
+
 `
 var eventsLong = `Execute Kubemq 'events' Pub/Sub commands`
 var eventsShort = `Execute Kubemq 'events' Pub/Sub commands`
@@ -29,7 +35,7 @@ func NewCmdEvents(ctx context.Context, cfg *config.Config) *cobra.Command {
 		Short:     eventsShort,
 		Long:      eventsLong,
 		Example:   eventsExamples,
-		ValidArgs: []string{"send", "receive", "attach"},
+		ValidArgs: []string{"send", "receive", "attach", "record", "replay"},
 		Run: func(cmd *cobra.Command, args []string) {
 			utils.CheckErr(cmd.Help())
 		},
@@ -37,6 +43,8 @@ func NewCmdEvents(ctx context.Context, cfg *config.Config) *cobra.Command {
 	cmd.AddCommand(NewCmdEventsSend(ctx, cfg))
 	cmd.AddCommand(NewCmdEventsReceive(ctx, cfg))
 	cmd.AddCommand(NewCmdEventsAttach(ctx, cfg))
+	cmd.AddCommand(NewCmdEventsRecord(ctx, cfg))
+	cmd.AddCommand(NewCmdEventsReplay(ctx, cfg))
 
 	return cmd
 }
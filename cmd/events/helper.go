@@ -1,43 +1,31 @@
 package events
 
 import (
-	b64 "encoding/base64"
 	"encoding/json"
-	"fmt"
+
 	kubemq "github.com/kubemq-io/kubemq-go"
+	"github.com/kubemq-io/kubemqctl/pkg/match"
+	"github.com/kubemq-io/kubemqctl/pkg/output"
 )
 
 type object struct {
-	Id         string            `json:"id"`
-	Channel    string            `json:"channel,omitempty"`
-	ClientId   string            `json:"client_id,omitempty"`
-	Metadata   string            `json:"metadata,omitempty"`
-	Tags       map[string]string `json:"tags,omitempty"`
-	BodyJson   json.RawMessage   `json:"body_json,omitempty"`
-	BodyString string            `json:"body_string,omitempty"`
+	Id       string            `json:"id"`
+	Channel  string            `json:"channel,omitempty"`
+	ClientId string            `json:"client_id,omitempty"`
+	Metadata string            `json:"metadata,omitempty"`
+	Tags     map[string]string `json:"tags,omitempty"`
+	output.Body
 }
 
 func newObjectWithEvent(event *kubemq.Event) *object {
 	obj := &object{
-		Id:         event.Id,
-		Channel:    event.Channel,
-		ClientId:   event.ClientId,
-		Metadata:   event.Metadata,
-		Tags:       event.Tags,
-		BodyJson:   json.RawMessage{},
-		BodyString: "",
-	}
-	var js json.RawMessage
-	if err := json.Unmarshal(event.Body, &js); err == nil {
-		obj.BodyJson = js
-	} else {
-		sDec, err := b64.StdEncoding.DecodeString(string(event.Body))
-		if err != nil {
-			obj.BodyString = string(event.Body)
-		} else {
-			obj.BodyString = string(sDec)
-		}
+		Id:       event.Id,
+		Channel:  event.Channel,
+		ClientId: event.ClientId,
+		Metadata: event.Metadata,
+		Tags:     event.Tags,
 	}
+	obj.Body = output.NewBody(event.Body)
 	return obj
 }
 
@@ -46,6 +34,17 @@ func (o *object) String() string {
 	return string(data)
 }
 
+func newFilterMessage(event *kubemq.Event) *match.Message {
+	return &match.Message{
+		Id:       event.Id,
+		Channel:  event.Channel,
+		ClientId: event.ClientId,
+		Metadata: event.Metadata,
+		Tags:     event.Tags,
+		Body:     event.Body,
+	}
+}
+
 func printEvent(event *kubemq.Event) {
-	fmt.Println(newObjectWithEvent(event))
+	output.Message(newObjectWithEvent(event))
 }
@@ -3,18 +3,29 @@ package events
 import (
 	"context"
 	"fmt"
+	kubemq2 "github.com/kubemq-io/kubemq-go"
 	"github.com/kubemq-io/kubemqctl/pkg/config"
 	"github.com/kubemq-io/kubemqctl/pkg/k8s"
 	"github.com/kubemq-io/kubemqctl/pkg/kubemq"
+	"github.com/kubemq-io/kubemqctl/pkg/match"
+	"github.com/kubemq-io/kubemqctl/pkg/output"
 	"github.com/kubemq-io/kubemqctl/pkg/utils"
 	"github.com/spf13/cobra"
+	"strings"
 )
 
 type EventsReceiveOptions struct {
-	cfg       *config.Config
-	transport string
-	channel   string
-	group     string
+	cfg        *config.Config
+	transport  string
+	channels   []string
+	group      string
+	reconnect  bool
+	color      bool
+	bodyOutput output.BodyOptions
+	tagFilters []string
+	tagFilter  match.TagFilter
+	filter     string
+	filterExpr *match.Filter
 }
 
 var eventsReceiveExamples = `
@@ -24,8 +35,25 @@ var eventsReceiveExamples = `
 	# Receive messages from an 'events' channel with group (blocks until next body)
 	kubemqctl events receive some-channel -g G1
 
+	# Receive messages from an 'events' channel and exit when the server disconnects
+	kubemqctl events receive some-channel --reconnect=false
+
+	# Receive messages from several 'events' channels in one stream, each message is tagged with its channel
+	kubemqctl events receive orders payments audit
+
+	# Receive messages from all 'events' channels under orders (server side wildcards, * matches one token and > matches the rest)
+	kubemqctl events receive "orders.*" "payments.>"
+
+	# Receive messages from an 'events' channel with a trace-id tag and an eu region tag
+	kubemqctl events receive some-channel --tag-filter trace-id=. --tag-filter region='^eu-'
+
+	# Receive messages from an 'events' channel with paid orders of a client
+	kubemqctl events receive some-channel --filter 'body.order.paid && client_id =~ "^billing-"'
+
+	# Receive messages from an 'events' channel and pipe the message bodies, one body per line, to a consumer
+	kubemqctl events receive some-channel --raw | consumer
 `
-var eventsReceiveLong = `Receive (Subscribe) command allows to consume one or many messages from 'events' channel`
+var eventsReceiveLong = `Receive (Subscribe) command allows to consume one or many messages from one or many 'events' channels, each channel has its own subscription and the messages are merged into one stream`
 var eventsReceiveShort = `Receive a body from 'events' channel command`
 
 func NewCmdEventsReceive(ctx context.Context, cfg *config.Config) *cobra.Command {
@@ -50,13 +78,31 @@ func NewCmdEventsReceive(ctx context.Context, cfg *config.Config) *cobra.Command
 	}
 
 	cmd.PersistentFlags().StringVarP(&o.group, "group", "g", "", "set 'events' channel consumer group (load balancing)")
+	cmd.PersistentFlags().BoolVarP(&o.reconnect, "reconnect", "", true, "set auto reconnect with exponential backoff when the subscription disconnects")
+	cmd.PersistentFlags().BoolVarP(&o.color, "color", "", true, "set colored channel tags when receiving from several channels")
+	cmd.PersistentFlags().StringArrayVarP(&o.tagFilters, "tag-filter", "", []string{}, "set print only messages with tags matching regexes in key=regex format, repeat to filter by several tags")
+	cmd.PersistentFlags().StringVarP(&o.filter, "filter", "", "", "set filter expression over body json paths, metadata, tags, channel, client_id and id, i.e. body.order.total > 100 && tags.region == \"eu\", messages which do not match are not printed")
+	o.bodyOutput.AddFlags(cmd)
 	return cmd
 }
 
 func (o *EventsReceiveOptions) Complete(args []string, transport string) error {
 	o.transport = transport
+	if err := o.bodyOutput.Complete(); err != nil {
+		return err
+	}
+	tagFilter, err := match.ParseTagFilter(o.tagFilters)
+	if err != nil {
+		return err
+	}
+	o.tagFilter = tagFilter
+	filter, err := match.ParseFilter(o.filter)
+	if err != nil {
+		return err
+	}
+	o.filterExpr = filter
 	if len(args) >= 1 {
-		o.channel = args[0]
+		o.channels = args
 		return nil
 	}
 	return fmt.Errorf("missing channel argument")
@@ -76,26 +122,44 @@ func (o *EventsReceiveOptions) Run(ctx context.Context) error {
 		client.Close()
 	}()
 
-	errChan := make(chan error, 1)
-	eventsChan, err := client.SubscribeToEvents(ctx, o.channel, o.group, errChan)
-
-	if err != nil {
-		utils.Println(fmt.Errorf("receive 'events' messages, %s", err.Error()).Error())
+	output.SetColor(o.color)
+	p := output.NewTaggedPrinter(len(o.channels) > 1 || strings.ContainsAny(o.channels[0], "*>"))
+	errCh := make(chan error, len(o.channels))
+	for _, channel := range o.channels {
+		go func(channel string) {
+			errCh <- o.receive(ctx, client, channel, p)
+		}(channel)
 	}
-	utils.Println("waiting for 'events' messages...")
-	for {
-		select {
-		case ev, opened := <-eventsChan:
-			if !opened {
-				utils.Println("server disconnected")
-				return nil
-			}
-			printEvent(ev)
-		case err := <-errChan:
-			return fmt.Errorf("server disconnected with error: %s", err.Error())
-		case <-ctx.Done():
-			return nil
+	for range o.channels {
+		if err := <-errCh; err != nil {
+			return err
 		}
 	}
+	return nil
+}
 
+func (o *EventsReceiveOptions) receive(ctx context.Context, client *kubemq2.Client, channel string, p *output.TaggedPrinter) error {
+	return kubemq.Subscribe(ctx, fmt.Sprintf("'events' %s subscription", channel), o.reconnect, func(ctx context.Context) error {
+		errChan := make(chan error, 1)
+		eventsChan, err := client.SubscribeToEvents(ctx, channel, o.group, errChan)
+		if err != nil {
+			return fmt.Errorf("receive 'events' messages, %s", err.Error())
+		}
+		utils.Printlnf("waiting for 'events' messages from %s...", channel)
+		for {
+			select {
+			case ev, opened := <-eventsChan:
+				if !opened {
+					return fmt.Errorf("server disconnected")
+				}
+				if o.tagFilter.Match(ev.Tags) && o.filterExpr.Match(newFilterMessage(ev)) {
+					p.Message(ev.Channel, newObjectWithEvent(ev))
+				}
+			case err := <-errChan:
+				return fmt.Errorf("server disconnected with error: %s", err.Error())
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	})
 }
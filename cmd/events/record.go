@@ -0,0 +1,160 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/kubemq-io/kubemqctl/pkg/config"
+	"github.com/kubemq-io/kubemqctl/pkg/k8s"
+	"github.com/kubemq-io/kubemqctl/pkg/kubemq"
+	"github.com/kubemq-io/kubemqctl/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+type EventsRecordOptions struct {
+	cfg       *config.Config
+	transport string
+	channel   string
+	group     string
+	fileName  string
+	messages  int
+	duration  time.Duration
+	reconnect bool
+}
+
+var eventsRecordExamples = `
+	# Record messages from an 'events' channel into some-channel.jsonl file until interrupted (Ctrl-C)
+	kubemqctl events record some-channel
+
+	# Record messages from an 'events' channel into traffic.jsonl file for 10 minutes
+	kubemqctl events record some-channel -f traffic.jsonl --duration 10m
+
+	# Record 1000 messages from an 'events' channel with group
+	kubemqctl events record some-channel -f traffic.jsonl -m 1000 -g G1
+`
+var eventsRecordLong = `Record command allows to save the messages of an 'events' channel with their metadata, tags and arrival time into a json lines file for a later replay.
+The recording file is set with -f/--file, -o is the global output format flag`
+var eventsRecordShort = `Record messages from an 'events' channel to a file command`
+
+func NewCmdEventsRecord(ctx context.Context, cfg *config.Config) *cobra.Command {
+	o := &EventsRecordOptions{
+		cfg: cfg,
+	}
+	cmd := &cobra.Command{
+
+		Use:     "record",
+		Aliases: []string{"rc"},
+		Short:   eventsRecordShort,
+		Long:    eventsRecordLong,
+		Example: eventsRecordExamples,
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			utils.CheckErr(o.Complete(args, cfg.ConnectionType), cmd)
+			utils.CheckErr(o.Validate())
+			utils.CheckErr(k8s.SetTransport(ctx, cfg))
+			utils.CheckErr(o.Run(ctx))
+		},
+	}
+	// -o is the global output format flag, so the recording file is set by -f
+	cmd.PersistentFlags().StringVarP(&o.fileName, "file", "f", "", "set recording file name, default <channel>.jsonl, -o is the global output format flag and does not set the recording file")
+	cmd.PersistentFlags().StringVarP(&o.group, "group", "g", "", "set 'events' channel consumer group (load balancing)")
+	cmd.PersistentFlags().IntVarP(&o.messages, "messages", "m", 0, "set how many 'events' messages to record, 0 records until interrupted")
+	cmd.PersistentFlags().DurationVarP(&o.duration, "duration", "", 0, "set how long to record, i.e. 10m, 0 records until interrupted")
+	cmd.PersistentFlags().BoolVarP(&o.reconnect, "reconnect", "", true, "set auto reconnect with exponential backoff when the subscription disconnects")
+	return cmd
+}
+
+func (o *EventsRecordOptions) Complete(args []string, transport string) error {
+	o.transport = transport
+	if len(args) >= 1 {
+		o.channel = args[0]
+	} else {
+		return fmt.Errorf("missing channel argument")
+	}
+	if o.fileName == "" {
+		o.fileName = fmt.Sprintf("%s.jsonl", o.channel)
+	}
+	return nil
+}
+
+func (o *EventsRecordOptions) Validate() error {
+	if o.messages < 0 {
+		return fmt.Errorf("messages cannot be negative")
+	}
+	if o.duration < 0 {
+		return fmt.Errorf("duration cannot be negative")
+	}
+	return nil
+}
+
+func (o *EventsRecordOptions) Run(ctx context.Context) error {
+	client, err := kubemq.GetKubemqClient(ctx, o.transport, o.cfg)
+	if err != nil {
+		return fmt.Errorf("create kubemq client, %s", err.Error())
+
+	}
+	defer func() {
+		client.Close()
+	}()
+	rw, err := newRecordWriter(o.fileName)
+	if err != nil {
+		return fmt.Errorf("create recording file, %s", err.Error())
+	}
+	defer rw.close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if o.duration > 0 {
+		ctx, cancel = context.WithTimeout(ctx, o.duration)
+		defer cancel()
+	}
+	// stop recording on interrupt so the summary is printed
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+	go func() {
+		select {
+		case <-sigCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	recorded := 0
+	startTime := time.Now()
+	utils.Printlnf("recording 'events' messages from %s channel to %s...", o.channel, o.fileName)
+	err = kubemq.Subscribe(ctx, "'events' subscription", o.reconnect, func(ctx context.Context) error {
+		errChan := make(chan error, 1)
+		eventsChan, err := client.SubscribeToEvents(ctx, o.channel, o.group, errChan)
+		if err != nil {
+			return fmt.Errorf("receive 'events' messages, %s", err.Error())
+		}
+		for {
+			select {
+			case ev, opened := <-eventsChan:
+				if !opened {
+					return fmt.Errorf("server disconnected")
+				}
+				if err := rw.write(newRecordedEvent(ev, time.Now())); err != nil {
+					return utils.StopReconnect(fmt.Errorf("write recording file, %s", err.Error()))
+				}
+				recorded++
+				if o.messages > 0 && recorded >= o.messages {
+					cancel()
+					return nil
+				}
+			case err := <-errChan:
+				return fmt.Errorf("server disconnected with error: %s", err.Error())
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	})
+	utils.Printlnf("%d 'events' messages recorded to %s in %s.", recorded, o.fileName, time.Since(startTime))
+	return err
+}
@@ -0,0 +1,116 @@
+package events
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	kubemq "github.com/kubemq-io/kubemq-go"
+)
+
+// recordedEvent is a single line of a recording file, recordings are saved as json lines so an interrupted record keeps all the events received so far
+type recordedEvent struct {
+	Time     time.Time         `json:"time"`
+	Channel  string            `json:"channel"`
+	Id       string            `json:"id"`
+	ClientId string            `json:"client_id,omitempty"`
+	Metadata string            `json:"metadata,omitempty"`
+	Tags     map[string]string `json:"tags,omitempty"`
+	Body     []byte            `json:"body"`
+}
+
+func newRecordedEvent(event *kubemq.Event, arrival time.Time) *recordedEvent {
+	return &recordedEvent{
+		Time:     arrival.UTC(),
+		Channel:  event.Channel,
+		Id:       event.Id,
+		ClientId: event.ClientId,
+		Metadata: event.Metadata,
+		Tags:     event.Tags,
+		Body:     event.Body,
+	}
+}
+
+func (r *recordedEvent) toEvent(event *kubemq.Event, channel string) *kubemq.Event {
+	if channel == "" {
+		channel = r.Channel
+	}
+	return event.
+		SetChannel(channel).
+		SetId(r.Id).
+		SetMetadata(r.Metadata).
+		SetTags(r.Tags).
+		SetBody(r.Body)
+}
+
+type recordWriter struct {
+	file *os.File
+	w    *bufio.Writer
+}
+
+func newRecordWriter(fileName string) (*recordWriter, error) {
+	f, err := os.Create(fileName)
+	if err != nil {
+		return nil, err
+	}
+	return &recordWriter{
+		file: f,
+		w:    bufio.NewWriter(f),
+	}, nil
+}
+
+func (rw *recordWriter) write(r *recordedEvent) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	if _, err := rw.w.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	return rw.w.Flush()
+}
+
+func (rw *recordWriter) close() error {
+	if err := rw.w.Flush(); err != nil {
+		rw.file.Close()
+		return err
+	}
+	return rw.file.Close()
+}
+
+func readRecording(r io.Reader) ([]*recordedEvent, error) {
+	var events []*recordedEvent
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 100*1024*1024)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		event := &recordedEvent{}
+		if err := json.Unmarshal([]byte(text), event); err != nil {
+			return nil, fmt.Errorf("invalid recorded event at line %d, %s", lineNumber, err.Error())
+		}
+		events = append(events, event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// parseSpeed parses a replay speed multiplier i.e. 2x, 0.5x or 2, 0 replays without delays
+func parseSpeed(value string) (float64, error) {
+	speed, err := strconv.ParseFloat(strings.TrimSuffix(strings.ToLower(strings.TrimSpace(value)), "x"), 64)
+	if err != nil || speed < 0 {
+		return 0, fmt.Errorf("invalid speed %s, speed must be a non negative multiplier i.e. 2x or 0.5x", value)
+	}
+	return speed, nil
+}
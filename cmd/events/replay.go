@@ -0,0 +1,130 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kubemq-io/kubemqctl/pkg/config"
+	"github.com/kubemq-io/kubemqctl/pkg/k8s"
+	"github.com/kubemq-io/kubemqctl/pkg/kubemq"
+	"github.com/kubemq-io/kubemqctl/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+type EventsReplayOptions struct {
+	cfg       *config.Config
+	transport string
+	fileName  string
+	channel   string
+	speedStr  string
+	speed     float64
+	events    []*recordedEvent
+}
+
+var eventsReplayExamples = `
+	# Replay a recording file to the recorded 'events' channels with the original timing
+	kubemqctl events replay traffic.jsonl
+
+	# Replay a recording file to some-channel 'events' channel at twice the original speed
+	kubemqctl events replay traffic.jsonl --channel some-channel --speed 2x
+
+	# Replay a recording file to some-channel 'events' channel as fast as possible
+	kubemqctl events replay traffic.jsonl --channel some-channel --speed 0
+`
+var eventsReplayLong = `Replay command allows to send (publish) the messages of a recording file to an 'events' channel with the original inter-arrival gaps, or at a multiple of that speed`
+var eventsReplayShort = `Replay a recording file to an 'events' channel command`
+
+func NewCmdEventsReplay(ctx context.Context, cfg *config.Config) *cobra.Command {
+	o := &EventsReplayOptions{
+		cfg: cfg,
+	}
+	cmd := &cobra.Command{
+
+		Use:     "replay",
+		Aliases: []string{"rp"},
+		Short:   eventsReplayShort,
+		Long:    eventsReplayLong,
+		Example: eventsReplayExamples,
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			utils.CheckErr(o.Complete(args, cfg.ConnectionType), cmd)
+			utils.CheckErr(o.Validate())
+			utils.CheckErr(k8s.SetTransport(ctx, cfg))
+			utils.CheckErr(o.Run(ctx))
+		},
+	}
+	cmd.PersistentFlags().StringVarP(&o.channel, "channel", "c", "", "set 'events' channel to replay to, default is the recorded channel of each message")
+	cmd.PersistentFlags().StringVarP(&o.speedStr, "speed", "", "1x", "set replay speed multiplier of the original timing, i.e. 2x, 0.5x, 0 replays without delays")
+	return cmd
+}
+
+func (o *EventsReplayOptions) Complete(args []string, transport string) error {
+	o.transport = transport
+	if len(args) >= 1 {
+		o.fileName = args[0]
+	} else {
+		return fmt.Errorf("missing recording file argument")
+	}
+	speed, err := parseSpeed(o.speedStr)
+	if err != nil {
+		return err
+	}
+	o.speed = speed
+	f, err := os.Open(o.fileName)
+	if err != nil {
+		return fmt.Errorf("open recording file, %s", err.Error())
+	}
+	defer f.Close()
+	o.events, err = readRecording(f)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (o *EventsReplayOptions) Validate() error {
+	if len(o.events) == 0 {
+		return fmt.Errorf("no recorded 'events' messages in %s", o.fileName)
+	}
+	return nil
+}
+
+func (o *EventsReplayOptions) Run(ctx context.Context) error {
+	client, err := kubemq.GetKubemqClient(ctx, o.transport, o.cfg)
+	if err != nil {
+		return fmt.Errorf("create kubemq client, %s", err.Error())
+	}
+
+	defer func() {
+		client.Close()
+	}()
+	utils.Printlnf("replaying %d 'events' messages from %s...", len(o.events), o.fileName)
+	startTime := time.Now()
+	first := o.events[0].Time
+	for i, rec := range o.events {
+		if o.speed > 0 {
+			// each message is scheduled relative to the replay start, so send latency does not accumulate into drift
+			at := startTime.Add(time.Duration(float64(rec.Time.Sub(first)) / o.speed))
+			if wait := time.Until(at); wait > 0 {
+				select {
+				case <-time.After(wait):
+				case <-ctx.Done():
+					return nil
+				}
+			}
+		}
+		msg := rec.toEvent(client.E(), o.channel)
+		if msg.Channel == "" {
+			return fmt.Errorf("missing channel for recorded message %d, set --channel flag", i+1)
+		}
+		if err := msg.Send(ctx); err != nil {
+			return fmt.Errorf("sending 'events' body, %s", err.Error())
+		}
+		printEvent(msg)
+	}
+	utils.Printlnf("%d 'events' messages replayed in %s.", len(o.events), time.Since(startTime))
+	return nil
+}
@@ -8,22 +8,31 @@ import (
 	"github.com/kubemq-io/kubemqctl/pkg/config"
 	"github.com/kubemq-io/kubemqctl/pkg/k8s"
 	"github.com/kubemq-io/kubemqctl/pkg/kubemq"
+	"github.com/kubemq-io/kubemqctl/pkg/pace"
 	"github.com/kubemq-io/kubemqctl/pkg/targets"
+	"github.com/kubemq-io/kubemqctl/pkg/template"
 	"github.com/kubemq-io/kubemqctl/pkg/utils"
 	"github.com/spf13/cobra"
 	"time"
 )
 
 type EventsSendOptions struct {
-	cfg       *config.Config
-	transport string
-	channel   string
-	body      string
-	metadata  string
-	messages  int
-	isStream  bool
-	fileName  bool
-	build     bool
+	cfg           *config.Config
+	transport     string
+	channel       string
+	body          string
+	metadata      string
+	messages      int
+	isStream      bool
+	fileName      bool
+	build         bool
+	buildMetadata string
+	buildData     string
+	messagesSet   bool
+	pacing        pace.Options
+	message       template.MessageOptions
+	tmpl          *template.MessageTemplate
+	bodyOptions   utils.BodyOptions
 }
 
 var eventsSendExamples = `
@@ -38,6 +47,24 @@ var eventsSendExamples = `
 
 	# Send (Publish) batch of 100 messages to a 'events' channel in stream mode
 	kubemqctl events send some-channel some-body -m 100 -s
+
+	# Send (Publish) 'events' messages at 50 messages per second for 10 minutes
+	kubemqctl events send some-channel some-body --rate 50/s --duration 10m
+
+	# Send (Publish) 1000 'events' messages at 100 messages per second in bursts of 10, ramping up during the first minute
+	kubemqctl events send some-channel some-body -m 1000 --rate 100/s --burst 10 --ramp-up 1m
+
+	# Send (Publish) 100 'events' messages with a templated body, each message has its own sequence, uuid and timestamp
+	kubemqctl events send some-channel -m 100 --body-template '{"seq":{{ .Seq }},"id":"{{ .Uuid }}","at":"{{ .Timestamp }}","score":{{ randInt 1 100 }}}'
+
+	# Send (Publish) an 'events' message for each row of users.csv, columns are available as .Row fields
+	kubemqctl events send some-channel -m 50 --body-template '{"user":"{{ .Row.name }}"}' --metadata '{{ .Row.region }}' --data-file users.csv
+
+	# Send (Publish) body to a 'events' channel with tags, with --body-template the tag values are templates
+	kubemqctl events send some-channel -m 10 --body-template '{"seq":{{ .Seq }}}' --tag region=eu --tag trace-id='{{ .Uuid }}'
+
+	# Send (Publish) body to a 'events' channel loaded from a file, or from stdin with -
+	kubemqctl events send some-channel @event.json
 `
 var eventsSendLong = `Send command allows to send (publish) one or many messages to an 'events' channel`
 var eventsSendShort = `Send messages to an 'events' channel command`
@@ -56,6 +83,7 @@ func NewCmdEventsSend(ctx context.Context, cfg *config.Config) *cobra.Command {
 		Run: func(cmd *cobra.Command, args []string) {
 			ctx, cancel := context.WithCancel(ctx)
 			defer cancel()
+			o.messagesSet = cmd.Flags().Changed("messages")
 			utils.CheckErr(o.Complete(args, cfg.ConnectionType), cmd)
 			utils.CheckErr(o.Validate())
 			utils.CheckErr(k8s.SetTransport(ctx, cfg))
@@ -65,21 +93,37 @@ func NewCmdEventsSend(ctx context.Context, cfg *config.Config) *cobra.Command {
 	cmd.PersistentFlags().StringVarP(&o.metadata, "metadata", "", "", "set body metadata field")
 	cmd.PersistentFlags().IntVarP(&o.messages, "messages", "m", 1, "set how many 'events' messages to send")
 	cmd.PersistentFlags().BoolVarP(&o.isStream, "stream", "s", false, "set stream of all messages at once")
-	cmd.PersistentFlags().BoolVarP(&o.fileName, "file", "f", false, "set body body from file")
+	cmd.PersistentFlags().BoolVarP(&o.fileName, "file", "f", false, "set load body from file, file name is set by the body argument")
 	cmd.PersistentFlags().BoolVarP(&o.build, "build", "b", false, "build kubemq targets request")
+	cmd.PersistentFlags().StringVarP(&o.buildMetadata, "build-metadata", "", "", "set kubemq targets request metadata in key1=value1,key2=value2 format")
+	cmd.PersistentFlags().StringVarP(&o.buildData, "build-data", "", "", "set kubemq targets request data file")
+	o.pacing.AddFlags(cmd)
+	o.message.AddFlags(cmd)
+	o.bodyOptions.AddFlags(cmd)
 	return cmd
 }
 
 func (o *EventsSendOptions) Complete(args []string, transport string) error {
 	o.transport = transport
+	if err := o.pacing.Complete(); err != nil {
+		return err
+	}
 	if len(args) >= 1 {
 		o.channel = args[0]
 
 	} else {
 		return fmt.Errorf("missing channel argument")
 	}
+	tmpl, err := o.message.Complete(o.metadata)
+	if err != nil {
+		return err
+	}
+	if tmpl != nil {
+		o.tmpl = tmpl
+		return nil
+	}
 	if o.build {
-		data, err := targets.BuildRequest()
+		data, err := targets.BuildRequest(o.buildMetadata, o.buildData)
 		if err != nil {
 			return err
 		}
@@ -87,22 +131,32 @@ func (o *EventsSendOptions) Complete(args []string, transport string) error {
 		return nil
 	}
 	if o.fileName {
-		data, err := targets.BuildFile()
+		fileName := ""
+		if len(args) >= 2 {
+			fileName = args[1]
+		}
+		data, err := targets.BuildFile(fileName)
 		if err != nil {
 			return err
 		}
 		o.body = string(data)
 	} else {
-		if len(args) >= 2 {
-			o.body = args[1]
-		} else {
-			return fmt.Errorf("missing body argument")
+		body, err := o.bodyOptions.Body(args[1:])
+		if err != nil {
+			return err
 		}
+		o.body = string(body)
 	}
 	return nil
 }
 
 func (o *EventsSendOptions) Validate() error {
+	if err := o.pacing.Validate(); err != nil {
+		return err
+	}
+	if o.isStream && o.pacing.IsPaced() {
+		return fmt.Errorf("--stream cannot be set with --rate or --duration")
+	}
 	return nil
 }
 
@@ -116,32 +170,45 @@ func (o *EventsSendOptions) Run(ctx context.Context) error {
 		client.Close()
 	}()
 
+	if o.pacing.IsPaced() {
+		return o.runPaced(ctx, client)
+	}
 	if o.isStream {
 		utils.Printlnf("Streaming %d events messages ...", o.messages)
 		eventsCh := make(chan *kubemq2.Event, 100)
 		errCh := make(chan error, 10)
-		fmt.Println("Sending Stream Events:")
+		utils.Println("Sending Stream Events:")
 		go client.StreamEvents(ctx, eventsCh, errCh)
 		startTime := time.Now()
 		for i := 1; i <= o.messages; i++ {
+			m, err := o.render(i - 1)
+			if err != nil {
+				return err
+			}
 			msg := client.E().
 				SetChannel(o.channel).
 				SetId(uuid.New().String()).
-				SetBody([]byte(o.body)).
-				SetMetadata(o.metadata)
+				SetBody(m.Body).
+				SetMetadata(m.Metadata).
+				SetTags(m.Tags)
 			printEvent(msg)
 			eventsCh <- msg
 		}
 		utils.Printlnf("%d events messages streamed in %s.", o.messages, time.Since(startTime))
 		time.Sleep(time.Second)
 	} else {
-		fmt.Println("Sending Events:")
+		utils.Println("Sending Events:")
 		for i := 1; i <= o.messages; i++ {
+			m, err := o.render(i - 1)
+			if err != nil {
+				return err
+			}
 			msg := client.E().
 				SetChannel(o.channel).
 				SetId(uuid.New().String()).
-				SetBody([]byte(o.body)).
-				SetMetadata(o.metadata)
+				SetBody(m.Body).
+				SetMetadata(m.Metadata).
+				SetTags(m.Tags)
 			err = msg.Send(ctx)
 			if err != nil {
 				return fmt.Errorf("sending 'events' body, %s", err.Error())
@@ -152,3 +219,27 @@ func (o *EventsSendOptions) Run(ctx context.Context) error {
 
 	return nil
 }
+
+func (o *EventsSendOptions) runPaced(ctx context.Context, client *kubemq2.Client) error {
+	utils.Printlnf("Sending 'events' messages to %s...", o.channel)
+	return o.pacing.Run(ctx, o.pacing.Count(o.messages, o.messagesSet), func(ctx context.Context, i int) error {
+		m, err := o.render(i)
+		if err != nil {
+			return err
+		}
+		return client.E().
+			SetChannel(o.channel).
+			SetId(uuid.New().String()).
+			SetBody(m.Body).
+			SetMetadata(m.Metadata).
+			SetTags(m.Tags).
+			Send(ctx)
+	}).Report()
+}
+
+func (o *EventsSendOptions) render(i int) (*template.Message, error) {
+	if o.tmpl == nil {
+		return o.message.Message([]byte(o.body), o.metadata), nil
+	}
+	return o.tmpl.Render(o.channel, i)
+}
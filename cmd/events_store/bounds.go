@@ -0,0 +1,136 @@
+package events_store
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	kubemq "github.com/kubemq-io/kubemq-go"
+	"github.com/kubemq-io/kubemqctl/pkg/utils"
+)
+
+type channelSummary struct {
+	Channel       string
+	Messages      int
+	FirstSequence uint64
+	LastSequence  uint64
+}
+
+// receiveTracker counts the received messages of each channel and stops the receive when the messages count limit is reached or no message was received for the idle timeout
+type receiveTracker struct {
+	sync.Mutex
+	limit    int
+	total    int
+	lastSeen time.Time
+	channels []*channelSummary
+	index    map[string]*channelSummary
+	cancel   context.CancelFunc
+}
+
+func newReceiveTracker(channels []string, limit int, cancel context.CancelFunc) *receiveTracker {
+	t := &receiveTracker{
+		limit:    limit,
+		lastSeen: time.Now(),
+		index:    map[string]*channelSummary{},
+		cancel:   cancel,
+	}
+	for _, channel := range channels {
+		summary := &channelSummary{Channel: channel}
+		t.channels = append(t.channels, summary)
+		t.index[channel] = summary
+	}
+	return t
+}
+
+// receive marks a message as seen for the idle and until time checks, whether or not it matched the filters,
+// and counts matched messages. It returns false when the messages count limit was already reached
+func (t *receiveTracker) receive(event *kubemq.EventStoreReceive, matched bool) bool {
+	t.Lock()
+	defer t.Unlock()
+	t.lastSeen = time.Now()
+	if !matched {
+		return true
+	}
+	if t.limit > 0 && t.total >= t.limit {
+		return false
+	}
+	t.total++
+	summary, ok := t.index[event.Channel]
+	if !ok {
+		summary = &channelSummary{Channel: event.Channel}
+		t.channels = append(t.channels, summary)
+		t.index[event.Channel] = summary
+	}
+	if summary.Messages == 0 {
+		summary.FirstSequence = event.Sequence
+	}
+	summary.Messages++
+	summary.LastSequence = event.Sequence
+	if t.limit > 0 && t.total >= t.limit {
+		t.cancel()
+	}
+	return true
+}
+
+func (t *receiveTracker) watchIdle(ctx context.Context, timeout time.Duration) {
+	ticker := time.NewTicker(timeout / 10)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			t.Lock()
+			idle := time.Since(t.lastSeen)
+			t.Unlock()
+			if idle >= timeout {
+				utils.Printlnf("no 'events store' messages received for %s, stopping", timeout)
+				t.cancel()
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// untilTimeGrace is how long the receive waits for messages after the until time passed, so the replay of a past time window is not cut short
+const untilTimeGrace = 2 * time.Second
+
+// watchUntil stops the receive once the until time passed and no message was received for the grace time,
+// a channel without messages after the until time would otherwise never end the receive
+func (t *receiveTracker) watchUntil(ctx context.Context, until time.Time) {
+	select {
+	case <-time.After(time.Until(until)):
+	case <-ctx.Done():
+		return
+	}
+	ticker := time.NewTicker(untilTimeGrace / 4)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			t.Lock()
+			idle := time.Since(t.lastSeen)
+			t.Unlock()
+			if idle >= untilTimeGrace {
+				utils.Printlnf("until time %s passed, stopping", until.Format("2006-01-02 15:04:05"))
+				t.cancel()
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// printSummary prints the status messages summary, structured output keeps only the messages documents on stdout
+func (t *receiveTracker) printSummary() {
+	t.Lock()
+	defer t.Unlock()
+	for _, summary := range t.channels {
+		if summary.Messages == 0 {
+			utils.Printlnf("0 'events store' messages received from %s", summary.Channel)
+			continue
+		}
+		utils.Printlnf("%d 'events store' messages received from %s, sequence %d to %d", summary.Messages, summary.Channel, summary.FirstSequence, summary.LastSequence)
+	}
+}
@@ -0,0 +1,48 @@
+package events_store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kubemq "github.com/kubemq-io/kubemq-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReceiveTracker_IdleWithFilteredMessages(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tracker := newReceiveTracker([]string{"c1"}, 0, cancel)
+	go tracker.watchIdle(ctx, 100*time.Millisecond)
+
+	// non matching messages keep arriving for 3 idle timeouts
+	for i := 0; i < 15; i++ {
+		require.True(t, tracker.receive(&kubemq.EventStoreReceive{Channel: "c1", Sequence: uint64(i + 1)}, false))
+		time.Sleep(20 * time.Millisecond)
+		require.NoError(t, ctx.Err(), "idle timeout fired while messages were received")
+	}
+	require.Equal(t, 0, tracker.total)
+	require.Equal(t, 0, tracker.index["c1"].Messages)
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		require.Fail(t, "idle timeout did not fire without messages")
+	}
+}
+
+func TestReceiveTracker_Limit(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tracker := newReceiveTracker([]string{"c1"}, 2, cancel)
+	require.True(t, tracker.receive(&kubemq.EventStoreReceive{Channel: "c1", Sequence: 5}, true))
+	require.True(t, tracker.receive(&kubemq.EventStoreReceive{Channel: "c1", Sequence: 6}, false))
+	require.NoError(t, ctx.Err())
+	require.True(t, tracker.receive(&kubemq.EventStoreReceive{Channel: "c2", Sequence: 7}, true))
+	require.Error(t, ctx.Err())
+	require.False(t, tracker.receive(&kubemq.EventStoreReceive{Channel: "c1", Sequence: 8}, true))
+
+	require.Equal(t, 2, tracker.total)
+	require.Equal(t, &channelSummary{Channel: "c1", Messages: 1, FirstSequence: 5, LastSequence: 5}, tracker.index["c1"])
+	require.Equal(t, &channelSummary{Channel: "c2", Messages: 1, FirstSequence: 7, LastSequence: 7}, tracker.index["c2"])
+}
@@ -0,0 +1,125 @@
+package events_store
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+type checkpointEntry struct {
+	Channel   string    `json:"channel"`
+	Group     string    `json:"group,omitempty"`
+	Sequence  uint64    `json:"sequence"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// checkpoints keeps the last processed sequence of each channel and group in a local file, the file is saved every interval updates and on shutdown
+type checkpoints struct {
+	sync.Mutex
+	fileName string
+	interval int
+	pending  int
+	Entries  []*checkpointEntry `json:"checkpoints"`
+}
+
+func loadCheckpoints(fileName string, interval int) (*checkpoints, error) {
+	c := &checkpoints{
+		fileName: fileName,
+		interval: interval,
+	}
+	data, err := ioutil.ReadFile(fileName)
+	if os.IsNotExist(err) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("invalid checkpoint file, %s", err.Error())
+	}
+	return c, nil
+}
+
+func (c *checkpoints) find(channel, group string) *checkpointEntry {
+	for _, entry := range c.Entries {
+		if entry.Channel == channel && entry.Group == group {
+			return entry
+		}
+	}
+	return nil
+}
+
+// last returns the last processed sequence of the channel and group, 0 when there is no checkpoint
+func (c *checkpoints) last(channel, group string) uint64 {
+	c.Lock()
+	defer c.Unlock()
+	if entry := c.find(channel, group); entry != nil {
+		return entry.Sequence
+	}
+	return 0
+}
+
+func (c *checkpoints) update(channel, group string, sequence uint64) error {
+	c.Lock()
+	defer c.Unlock()
+	entry := c.find(channel, group)
+	if entry == nil {
+		entry = &checkpointEntry{
+			Channel: channel,
+			Group:   group,
+		}
+		c.Entries = append(c.Entries, entry)
+	}
+	entry.Sequence = sequence
+	entry.UpdatedAt = time.Now().UTC()
+	c.pending++
+	if c.pending < c.interval {
+		return nil
+	}
+	return c.save()
+}
+
+func (c *checkpoints) flush() error {
+	c.Lock()
+	defer c.Unlock()
+	if c.pending == 0 {
+		return nil
+	}
+	return c.save()
+}
+
+// save writes the checkpoints to a temporary file and renames it, so the checkpoint file is never partially written
+func (c *checkpoints) save() error {
+	data, err := json.MarshalIndent(c, "", "    ")
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(c.fileName), filepath.Base(c.fileName)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), c.fileName); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	c.pending = 0
+	return nil
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/go-resty/resty/v2"
 	"github.com/kubemq-io/kubemqctl/pkg/config"
 	"github.com/kubemq-io/kubemqctl/pkg/k8s"
+	"github.com/kubemq-io/kubemqctl/pkg/kubemq"
+	"github.com/kubemq-io/kubemqctl/pkg/output"
 	"github.com/kubemq-io/kubemqctl/pkg/utils"
 	"github.com/spf13/cobra"
 	"os"
@@ -19,6 +21,10 @@ type EventsStoreListOptions struct {
 	cfg       *config.Config
 	transport string
 	filter    string
+	showLag   bool
+	lag       kubemq.LagOptions
+	watch     bool
+	interval  time.Duration
 }
 
 var eventsStoreListExamples = `
@@ -27,6 +33,16 @@ var eventsStoreListExamples = `
 	
 	# Get a list of events stores channels/ clients filtered by 'some-events-store' channel only
 	kubemqctl events_store list -f some-events-store
+
+	# Get the consumer lag of each client, the lag is the channel last sequence minus the client last sent sequence,
+	# the lag of a consumer group is the min lag of its members
+	kubemqctl events_store list --lag
+
+	# Alert check, exit with code 2 when a client lag is over 1000 messages or a client is stalled
+	kubemqctl events_store list --max-lag 1000 --fail-stalled
+
+	# Watch 'events store' channels rates every 2 seconds, the terminal is refreshed in place, otherwise each snapshot is written as a json line
+	kubemqctl events_store list --watch --interval 2s
 `
 var eventsStoreListLong = `List command allows to get a list of 'events store' channels / clients with details`
 var eventsStoreListShort = `Get a list of 'events store' channels / clients command`
@@ -52,39 +68,85 @@ func NewCmdEventsStoreList(ctx context.Context, cfg *config.Config) *cobra.Comma
 		},
 	}
 	cmd.PersistentFlags().StringVarP(&o.filter, "filter", "f", "", "set filter for channel / client name")
+	cmd.PersistentFlags().BoolVarP(&o.showLag, "lag", "", false, "set show consumer lag report of the clients")
+	cmd.PersistentFlags().Int64VarP(&o.lag.MinLag, "min-lag", "", 0, "set show only clients with at least this lag")
+	cmd.PersistentFlags().Int64VarP(&o.lag.MaxLag, "max-lag", "", 0, "set exit with code 2 when a client lag is over this limit, a client in a consumer group is checked by the group lag, implies --lag")
+	cmd.PersistentFlags().BoolVarP(&o.lag.FailStalled, "fail-stalled", "", false, "set exit with code 2 when a client is stalled, implies --lag")
+	cmd.PersistentFlags().StringVarP(&o.lag.Sort, "sort", "", "lag", "set lag report sort field, one of lag, pending, channel or client")
+	cmd.PersistentFlags().BoolVarP(&o.watch, "watch", "w", false, "set watch mode, re-poll the stats every interval and show messages and bytes rates per channel, clients changes and pending growth")
+	cmd.PersistentFlags().DurationVarP(&o.interval, "interval", "", 2*time.Second, "set watch mode poll interval")
 	return cmd
 }
 
 func (o *EventsStoreListOptions) Complete(args []string, transport string) error {
 	o.transport = transport
+	if o.lag.MaxLag > 0 || o.lag.FailStalled {
+		o.showLag = true
+	}
 	return nil
 }
 
 func (o *EventsStoreListOptions) Validate() error {
-	return nil
+	if o.watch && o.interval <= 0 {
+		return fmt.Errorf("watch interval must be greater than 0")
+	}
+	if o.watch && o.showLag {
+		return fmt.Errorf("only one of --watch or lag report flags can be set")
+	}
+	return o.lag.Validate()
 }
 
 func (o *EventsStoreListOptions) Run(ctx context.Context) error {
+	if o.watch {
+		return kubemq.Watch(ctx, o.interval, func() ([]*kubemq.ChannelStats, error) {
+			q, err := o.fetch()
+			if err != nil {
+				return nil, err
+			}
+			return q.channelStats(o.filter), nil
+		})
+	}
+	q, err := o.fetch()
+	if err != nil {
+		return err
+	}
+	if o.showLag {
+		clients := q.clientLags(o.filter)
+		if !output.IsText() {
+			if err := output.Print(o.lag.Report(clients)); err != nil {
+				return err
+			}
+		} else {
+			kubemq.PrintLagTab(os.Stdout, o.lag.Report(clients))
+		}
+		return o.lag.Check(clients)
+	}
+	if !output.IsText() {
+		return output.Print(q.filtered(o.filter))
+	}
+	q.printChannelsTab(o.filter)
+	q.printClientsTab(o.filter)
+	return nil
+}
+
+func (o *EventsStoreListOptions) fetch() (*Queues, error) {
 	resp := &Response{}
 	q := &Queues{}
-
 	r, err := resty.New().R().SetResult(resp).SetError(resp).Get(fmt.Sprintf("%s/v1/stats/events_stores", o.cfg.GetApiHttpURI()))
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if !r.IsSuccess() {
-		return fmt.Errorf("not available in current Kubemq version, consider upgrade Kubemq version")
+		return nil, fmt.Errorf("not available in current Kubemq version, consider upgrade Kubemq version")
 	}
 	if resp.Error {
-		return fmt.Errorf(resp.ErrorString)
+		return nil, fmt.Errorf(resp.ErrorString)
 	}
 	err = json.Unmarshal(resp.Data, q)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	q.printChannelsTab(o.filter)
-	q.printClientsTab(o.filter)
-	return nil
+	return q, nil
 }
 
 type Response struct {
@@ -111,20 +173,56 @@ type Queue struct {
 
 type Client struct {
 	ClientId         string `json:"client_id"`
+	Group            string `json:"group"`
 	Active           bool   `json:"active"`
 	LastSequenceSent int64  `json:"last_sequence_sent"`
 	IsStalled        bool   `json:"is_stalled"`
 	Pending          int64  `json:"pending"`
 }
 
+func (q *Queues) filtered(filter string) *Queues {
+	if filter == "" {
+		return q
+	}
+	result := &Queues{
+		Now: q.Now,
+	}
+	for _, item := range q.Queues {
+		if strings.Contains(item.Name, filter) {
+			result.Queues = append(result.Queues, item)
+		}
+	}
+	result.Total = len(result.Queues)
+	return result
+}
+
 func (q *Queues) printChannelsTab(filter string) {
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.TabIndent)
 	fmt.Fprintf(w, "CHANNELS:\n")
-	fmt.Fprintln(w, "NAME\tCLIENTS\tMESSAGES\tBYTES\tFIRST_SEQUENCE\tLAST_SEQUENCE")
+	if output.IsWide() {
+		fmt.Fprintln(w, "NAME\tCLIENTS\tACTIVE_CLIENTS\tSTALLED_CLIENTS\tMESSAGES\tBYTES\tPENDING\tFIRST_SEQUENCE\tLAST_SEQUENCE")
+	} else {
+		fmt.Fprintln(w, "NAME\tCLIENTS\tMESSAGES\tBYTES\tFIRST_SEQUENCE\tLAST_SEQUENCE")
+	}
 	cnt := 0
 	for _, q := range q.Queues {
 		if filter == "" || strings.Contains(q.Name, filter) {
-			fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%d\n", q.Name, len(q.Clients), q.Messages, q.Bytes, q.FirstSequence, q.LastSequence)
+			if output.IsWide() {
+				var active, stalled int
+				var pending int64
+				for _, c := range q.Clients {
+					if c.Active {
+						active++
+					}
+					if c.IsStalled {
+						stalled++
+					}
+					pending += c.Pending
+				}
+				fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%d\t%d\t%d\t%d\n", q.Name, len(q.Clients), active, stalled, q.Messages, q.Bytes, pending, q.FirstSequence, q.LastSequence)
+			} else {
+				fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%d\n", q.Name, len(q.Clients), q.Messages, q.Bytes, q.FirstSequence, q.LastSequence)
+			}
 			cnt++
 		}
 
@@ -135,7 +233,11 @@ func (q *Queues) printChannelsTab(filter string) {
 func (q *Queues) printClientsTab(filter string) {
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.TabIndent)
 	fmt.Fprintf(w, "\nCLIENTS:\n")
-	fmt.Fprintln(w, "CLIENT_ID\tCHANNEL\tACTIVE\tLAST_SENT\tPENDING\tSTALLED")
+	if output.IsWide() {
+		fmt.Fprintln(w, "CLIENT_ID\tCHANNEL\tACTIVE\tCHANNEL_FIRST_SEQUENCE\tCHANNEL_LAST_SEQUENCE\tLAST_SENT\tPENDING\tSTALLED")
+	} else {
+		fmt.Fprintln(w, "CLIENT_ID\tCHANNEL\tACTIVE\tLAST_SENT\tPENDING\tSTALLED")
+	}
 	cnt := 0
 	for _, q := range q.Queues {
 		for _, c := range q.Clients {
@@ -144,7 +246,11 @@ func (q *Queues) printClientsTab(filter string) {
 					c.ClientId = "N/A"
 				}
 				cnt++
-				fmt.Fprintf(w, "%s\t%s\t%t\t%d\t%d\t%t\n", c.ClientId, q.Name, c.Active, c.LastSequenceSent, c.Pending, c.IsStalled)
+				if output.IsWide() {
+					fmt.Fprintf(w, "%s\t%s\t%t\t%d\t%d\t%d\t%d\t%t\n", c.ClientId, q.Name, c.Active, q.FirstSequence, q.LastSequence, c.LastSequenceSent, c.Pending, c.IsStalled)
+				} else {
+					fmt.Fprintf(w, "%s\t%s\t%t\t%d\t%d\t%t\n", c.ClientId, q.Name, c.Active, c.LastSequenceSent, c.Pending, c.IsStalled)
+				}
 			}
 		}
 
@@ -152,3 +258,61 @@ func (q *Queues) printClientsTab(filter string) {
 	fmt.Fprintf(w, "\nTOTAL CLIENTS:\t%d\n", cnt)
 	w.Flush()
 }
+
+// clientLags returns the lag of each client and of its consumer group, clients are filtered like the clients list by channel or client name
+func (q *Queues) clientLags(filter string) []*kubemq.ClientLag {
+	var clients []*kubemq.ClientLag
+	for _, q := range q.Queues {
+		for _, c := range q.Clients {
+			if filter != "" && !strings.Contains(c.ClientId, filter) && !strings.Contains(q.Name, filter) {
+				continue
+			}
+			lag := q.LastSequence - c.LastSequenceSent
+			if lag < 0 {
+				lag = 0
+			}
+			clientId := c.ClientId
+			if clientId == "" {
+				clientId = "N/A"
+			}
+			clients = append(clients, &kubemq.ClientLag{
+				Channel:          q.Name,
+				ClientId:         clientId,
+				Group:            c.Group,
+				Active:           c.Active,
+				LastSequence:     q.LastSequence,
+				LastSequenceSent: c.LastSequenceSent,
+				Lag:              lag,
+				Pending:          c.Pending,
+				Stalled:          c.IsStalled,
+			})
+		}
+	}
+	return clients
+}
+
+// channelStats returns the watch snapshot of the channels, channels are filtered like the channels list by channel name
+func (q *Queues) channelStats(filter string) []*kubemq.ChannelStats {
+	var channels []*kubemq.ChannelStats
+	for _, q := range q.Queues {
+		if filter != "" && !strings.Contains(q.Name, filter) {
+			continue
+		}
+		ch := &kubemq.ChannelStats{
+			Name:         q.Name,
+			Messages:     q.Messages,
+			Bytes:        q.Bytes,
+			LastSequence: q.LastSequence,
+			Clients:      map[string]int64{},
+		}
+		for _, c := range q.Clients {
+			clientId := c.ClientId
+			if clientId == "" {
+				clientId = "N/A"
+			}
+			ch.Clients[clientId] += c.Pending
+		}
+		channels = append(channels, ch)
+	}
+	return channels
+}
@@ -8,16 +8,21 @@ import (
 	"github.com/kubemq-io/kubemqctl/pkg/config"
 	"github.com/kubemq-io/kubemqctl/pkg/k8s"
 	"github.com/kubemq-io/kubemqctl/pkg/kubemq"
+	"github.com/kubemq-io/kubemqctl/pkg/match"
+	"github.com/kubemq-io/kubemqctl/pkg/output"
 	"github.com/kubemq-io/kubemqctl/pkg/utils"
 	"github.com/spf13/cobra"
+	"os"
+	"os/signal"
 	"strconv"
+	"syscall"
 	"time"
 )
 
 type EventsStoreReceiveOptions struct {
 	cfg           *config.Config
 	transport     string
-	channel       string
+	channels      []string
 	group         string
 	startNew      bool
 	startFirst    bool
@@ -27,6 +32,21 @@ type EventsStoreReceiveOptions struct {
 	startDuration string
 	hasFlags      bool
 	subOptions    kubemq2.SubscriptionOption
+	reconnect     bool
+	color         bool
+	untilSequence int
+	untilTimeStr  string
+	untilTime     time.Time
+	count         int
+	idleTimeout   time.Duration
+	checkpoint    string
+	checkpointN   int
+	checkpoints   *checkpoints
+	bodyOutput    output.BodyOptions
+	tagFilters    []string
+	tagFilter     match.TagFilter
+	filter        string
+	filterExpr    *match.Filter
 }
 
 var eventsReceiveExamples = `
@@ -35,8 +55,32 @@ var eventsReceiveExamples = `
 
 	# Receive messages from an 'events channel' with group(blocks until next body)
 	kubemqctl events_store receive some-channel -g G1
+
+	# Receive messages from the first message of an 'events store' channel without prompts
+	kubemqctl events_store receive some-channel --start-first --no-input
+
+	# Receive messages from an 'events store' channel and exit when the server disconnects
+	kubemqctl events_store receive some-channel --reconnect=false
+
+	# Receive messages from several 'events store' channels in one stream, each message is tagged with its channel
+	kubemqctl events_store receive orders payments --start-new
+
+	# Receive messages from all existing 'events store' channels matching a glob pattern
+	kubemqctl events_store receive "orders.*" --start-first
+
+	# Receive messages from sequence 100 to sequence 200 of an 'events store' channel and exit
+	kubemqctl events_store receive some-channel --start-sequence 100 --until-sequence 200
+
+	# Export a time window of an 'events store' channel as json lines, exit once the window ended
+	kubemqctl events_store receive some-channel --start-time "2026-01-01 10:00:00" --until-time "2026-01-01 11:00:00" -o json > window.jsonl
+
+	# Receive the first 10 messages of an 'events store' channel and exit
+	kubemqctl events_store receive some-channel --start-first --count 10
+
+	# Process an 'events store' channel incrementally, each run resumes after the last sequence saved in the checkpoint file
+	kubemqctl events_store receive some-channel --checkpoint some-channel.checkpoint --idle-timeout 5s
 `
-var eventsReceiveLong = `Receive (Subscribe) command allows to consume messages from an 'events store' with options to set offset parameters`
+var eventsReceiveLong = `Receive (Subscribe) command allows to consume messages from one or many 'events store' channels with options to set offset parameters. Glob patterns (*, ?, [...]) are expanded to the existing channels when the command starts, each channel has its own subscription and the messages are merged into one stream`
 var eventsReceiveShort = `Receive a messages from an 'events store'`
 
 func NewCmdEventsStoreReceive(ctx context.Context, cfg *config.Config) *cobra.Command {
@@ -67,16 +111,54 @@ func NewCmdEventsStoreReceive(ctx context.Context, cfg *config.Config) *cobra.Co
 	cmd.PersistentFlags().IntVar(&o.startSequence, "start-sequence", 0, "start from body sequence")
 	cmd.PersistentFlags().StringVar(&o.startTime, "start-time", "", "start from timestamp format 2006-01-02 15:04:05")
 	cmd.PersistentFlags().StringVar(&o.startDuration, "start-duration", "", "start from time duration i.e. 1h")
+	cmd.PersistentFlags().BoolVarP(&o.reconnect, "reconnect", "", true, "set auto reconnect with exponential backoff when the subscription disconnects, resuming from the last received sequence")
+	cmd.PersistentFlags().BoolVarP(&o.color, "color", "", true, "set colored channel tags when receiving from several channels")
+	cmd.PersistentFlags().IntVar(&o.untilSequence, "until-sequence", 0, "set stop receiving a channel after its message with this sequence")
+	cmd.PersistentFlags().StringVar(&o.untilTimeStr, "until-time", "", "set stop receiving a channel at its first message after this timestamp, or once the timestamp passed and no message arrives for 2 seconds, format 2006-01-02 15:04:05 (UTC)")
+	cmd.PersistentFlags().IntVar(&o.count, "count", 0, "set stop receiving after this number of messages")
+	cmd.PersistentFlags().DurationVar(&o.idleTimeout, "idle-timeout", 0, "set stop receiving when no message is received for this duration, i.e. 5s")
+	cmd.PersistentFlags().StringVar(&o.checkpoint, "checkpoint", "", "set checkpoint file of the last processed sequence per channel and group, channels with a checkpoint resume from the next sequence, other channels start from the start flags or the first message")
+	cmd.PersistentFlags().IntVar(&o.checkpointN, "checkpoint-interval", 100, "set how many messages to process between checkpoint file saves, the file is saved on exit as well")
+	cmd.PersistentFlags().StringArrayVarP(&o.tagFilters, "tag-filter", "", []string{}, "set print only messages with tags matching regexes in key=regex format, repeat to filter by several tags, --count counts the matching messages only")
+	cmd.PersistentFlags().StringVarP(&o.filter, "filter", "", "", "set filter expression over body json paths, metadata, tags, channel, client_id and id, i.e. body.order.total > 100 && tags.region == \"eu\", messages which do not match are not printed and not counted by --count")
+	o.bodyOutput.AddFlags(cmd)
 	return cmd
 }
 
 func (o *EventsStoreReceiveOptions) Complete(args []string, transport string) error {
 	o.transport = transport
+	if err := o.bodyOutput.Complete(); err != nil {
+		return err
+	}
+	tagFilter, err := match.ParseTagFilter(o.tagFilters)
+	if err != nil {
+		return err
+	}
+	o.tagFilter = tagFilter
+	filter, err := match.ParseFilter(o.filter)
+	if err != nil {
+		return err
+	}
+	o.filterExpr = filter
 	if len(args) >= 1 {
-		o.channel = args[0]
+		o.channels = args
 	} else {
 		return fmt.Errorf("missing channel argument")
 	}
+	if o.untilTimeStr != "" {
+		t, err := time.Parse("2006-01-02 15:04:05", o.untilTimeStr)
+		if err != nil {
+			return fmt.Errorf("until time format error, %s", err.Error())
+		}
+		o.untilTime = t.UTC()
+	}
+	if o.checkpoint != "" {
+		c, err := loadCheckpoints(o.checkpoint, o.checkpointN)
+		if err != nil {
+			return fmt.Errorf("load checkpoint file, %s", err.Error())
+		}
+		o.checkpoints = c
+	}
 
 	if o.startNew {
 		o.subOptions = kubemq2.StartFromNewEvents()
@@ -110,7 +192,11 @@ func (o *EventsStoreReceiveOptions) Complete(args []string, transport string) er
 		o.subOptions = kubemq2.StartFromTimeDelta(d)
 		return nil
 	}
-	err := o.promptOptions()
+	if o.checkpoints != nil {
+		o.subOptions = kubemq2.StartFromFirstEvent()
+		return nil
+	}
+	err = o.promptOptions()
 	if err != nil {
 		return err
 	}
@@ -118,9 +204,28 @@ func (o *EventsStoreReceiveOptions) Complete(args []string, transport string) er
 }
 
 func (o *EventsStoreReceiveOptions) Validate() error {
+	if o.untilSequence < 0 {
+		return fmt.Errorf("until sequence cannot be negative")
+	}
+	if o.count < 0 {
+		return fmt.Errorf("count cannot be negative")
+	}
+	if o.idleTimeout < 0 {
+		return fmt.Errorf("idle timeout cannot be negative")
+	}
+	if o.checkpointN <= 0 {
+		return fmt.Errorf("checkpoint interval must be greater than 0")
+	}
+	if o.untilSequence > 0 && o.startSequence > o.untilSequence {
+		return fmt.Errorf("until sequence must be greater or equal to start sequence")
+	}
 	return nil
 }
 
+func (o *EventsStoreReceiveOptions) isBounded() bool {
+	return o.untilSequence > 0 || !o.untilTime.IsZero() || o.count > 0 || o.idleTimeout > 0
+}
+
 func (o *EventsStoreReceiveOptions) Run(ctx context.Context) error {
 	client, err := kubemq.GetKubemqClient(ctx, o.transport, o.cfg)
 	if err != nil {
@@ -131,29 +236,121 @@ func (o *EventsStoreReceiveOptions) Run(ctx context.Context) error {
 		client.Close()
 	}()
 
-	errChan := make(chan error, 1)
-	eventsChan, err := client.SubscribeToEventsStore(ctx, o.channel, o.group, errChan, o.subOptions)
-
-	if err != nil {
-		utils.Println(fmt.Errorf("receive 'events store' messages, %s", err.Error()).Error())
+	channels := o.channels
+	for _, channel := range o.channels {
+		if kubemq.IsWildcard(channel) {
+			list, err := kubemq.ListChannels(o.cfg, "events_stores")
+			if err != nil {
+				return fmt.Errorf("list 'events store' channels, %s", err.Error())
+			}
+			channels, err = kubemq.MatchChannels(o.channels, list)
+			if err != nil {
+				return err
+			}
+			break
+		}
 	}
-	utils.Println("waiting for 'events store' messages...")
-
-	for {
-		select {
-		case ev, opened := <-eventsChan:
-			if !opened {
-				utils.Println("server disconnected")
-				return nil
+	output.SetColor(o.color)
+	p := output.NewTaggedPrinter(len(o.channels) > 1 || kubemq.IsWildcard(o.channels[0]))
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	t := newReceiveTracker(channels, o.count, cancel)
+	if o.idleTimeout > 0 {
+		go t.watchIdle(ctx, o.idleTimeout)
+	}
+	if !o.untilTime.IsZero() {
+		go t.watchUntil(ctx, o.untilTime)
+	}
+	if o.checkpoints != nil {
+		// stop on interrupt so the checkpoint file is saved
+		sigCh := make(chan os.Signal, 1)
+		signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+		defer signal.Stop(sigCh)
+		go func() {
+			select {
+			case <-sigCh:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+		defer func() {
+			if err := o.checkpoints.flush(); err != nil {
+				utils.Printlnf("save checkpoint file error, %s", err.Error())
 			}
-			printEventReceive(ev)
-		case err := <-errChan:
-			return fmt.Errorf("server disconnected with error: %s", err.Error())
-		case <-ctx.Done():
-			return nil
+		}()
+	}
+	errCh := make(chan error, len(channels))
+	for _, channel := range channels {
+		go func(channel string) {
+			errCh <- o.receive(ctx, client, channel, p, t)
+		}(channel)
+	}
+	for range channels {
+		if err := <-errCh; err != nil {
+			return err
 		}
 	}
+	if o.isBounded() {
+		t.printSummary()
+	}
+	return nil
+}
 
+func (o *EventsStoreReceiveOptions) receive(ctx context.Context, client *kubemq2.Client, channel string, p *output.TaggedPrinter, t *receiveTracker) error {
+	// done ends the channel subscription when one of its until conditions is met
+	ctx, done := context.WithCancel(ctx)
+	defer done()
+	// lastSequence is the last received sequence, a reconnected subscription resumes from the next one
+	var lastSequence uint64
+	if o.checkpoints != nil {
+		lastSequence = o.checkpoints.last(channel, o.group)
+	}
+	return kubemq.Subscribe(ctx, fmt.Sprintf("'events store' %s subscription", channel), o.reconnect, func(ctx context.Context) error {
+		subOptions := o.subOptions
+		if lastSequence > 0 {
+			subOptions = kubemq2.StartFromSequence(int(lastSequence + 1))
+		}
+		errChan := make(chan error, 1)
+		eventsChan, err := client.SubscribeToEventsStore(ctx, channel, o.group, errChan, subOptions)
+		if err != nil {
+			return fmt.Errorf("receive 'events store' messages, %s", err.Error())
+		}
+		utils.Printlnf("waiting for 'events store' messages from %s...", channel)
+		for {
+			select {
+			case ev, opened := <-eventsChan:
+				if !opened {
+					return fmt.Errorf("server disconnected")
+				}
+				if o.untilSequence > 0 && ev.Sequence > uint64(o.untilSequence) ||
+					!o.untilTime.IsZero() && ev.Timestamp.After(o.untilTime) {
+					done()
+					return nil
+				}
+				matched := o.tagFilter.Match(ev.Tags) && o.filterExpr.Match(newFilterMessage(ev))
+				if !t.receive(ev, matched) {
+					return nil
+				}
+				lastSequence = ev.Sequence
+				if matched {
+					p.Message(ev.Channel, newObjectWithEventReceive(ev))
+				}
+				if o.checkpoints != nil {
+					if err := o.checkpoints.update(channel, o.group, ev.Sequence); err != nil {
+						return utils.StopReconnect(fmt.Errorf("save checkpoint file, %s", err.Error()))
+					}
+				}
+				if o.untilSequence > 0 && ev.Sequence == uint64(o.untilSequence) {
+					done()
+					return nil
+				}
+			case err := <-errChan:
+				return fmt.Errorf("server disconnected with error: %s", err.Error())
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	})
 }
 
 func (o *EventsStoreReceiveOptions) promptOptions() error {
@@ -162,7 +359,7 @@ func (o *EventsStoreReceiveOptions) promptOptions() error {
 		Message: "Start receive events store messages options:",
 		Options: []string{"start from new messages only", "start from first body", "start from last body", "start from sequence", "start from time", "start from duration"},
 	}
-	err := survey.AskOne(prompt, &action)
+	err := utils.AskOne(prompt, &action, "--start-new, --start-first, --start-last, --start-sequence, --start-time or --start-duration flag")
 	if err != nil {
 		return err
 	}
@@ -185,7 +382,7 @@ func (o *EventsStoreReceiveOptions) promptOptions() error {
 			Help:     "1 is the first body",
 		}
 
-		err := survey.AskOne(prompt, &seqStr)
+		err := utils.AskOne(prompt, &seqStr, "--start-sequence flag")
 		if err != nil {
 			return err
 		}
@@ -205,7 +402,7 @@ func (o *EventsStoreReceiveOptions) promptOptions() error {
 			Help:     "Time format '2006-01-02 15:04:05'",
 		}
 
-		err := survey.AskOne(prompt, &timeStr)
+		err := utils.AskOne(prompt, &timeStr, "--start-time flag")
 		if err != nil {
 			return err
 		}
@@ -225,7 +422,7 @@ func (o *EventsStoreReceiveOptions) promptOptions() error {
 			Help:     "Duration time such 1s, 1h, 24h",
 		}
 
-		err := survey.AskOne(prompt, &durationStr)
+		err := utils.AskOne(prompt, &durationStr, "--start-duration flag")
 		if err != nil {
 			return err
 		}
@@ -8,22 +8,31 @@ import (
 	"github.com/kubemq-io/kubemqctl/pkg/config"
 	"github.com/kubemq-io/kubemqctl/pkg/k8s"
 	"github.com/kubemq-io/kubemqctl/pkg/kubemq"
+	"github.com/kubemq-io/kubemqctl/pkg/pace"
 	"github.com/kubemq-io/kubemqctl/pkg/targets"
+	"github.com/kubemq-io/kubemqctl/pkg/template"
 	"github.com/kubemq-io/kubemqctl/pkg/utils"
 	"github.com/spf13/cobra"
 	"time"
 )
 
 type EventsStoreSendOptions struct {
-	cfg       *config.Config
-	transport string
-	channel   string
-	body      string
-	metadata  string
-	messages  int
-	isStream  bool
-	fileName  bool
-	build     bool
+	cfg           *config.Config
+	transport     string
+	channel       string
+	body          string
+	metadata      string
+	messages      int
+	isStream      bool
+	fileName      bool
+	build         bool
+	buildMetadata string
+	buildData     string
+	messagesSet   bool
+	pacing        pace.Options
+	message       template.MessageOptions
+	tmpl          *template.MessageTemplate
+	bodyOptions   utils.BodyOptions
 }
 
 var eventsSendExamples = `
@@ -38,6 +47,18 @@ var eventsSendExamples = `
 
 	# Send 100 messages to an 'events store' channel in stream mode
 	kubemqctl events_store send some-channel some-body -m 100 -s
+
+	# Send 'events store' messages at 600 messages per minute with 20% interval jitter for 1 hour
+	kubemqctl events_store send some-channel some-body --rate 600/m --jitter 0.2 --duration 1h
+
+	# Send 1000 'events store' messages with templated body and metadata
+	kubemqctl events_store send some-channel -m 1000 --body-template '{"seq":{{ .Seq }},"key":"{{ randAlphaNum 8 }}"}' --metadata 'batch-{{ div .Index 100 }}'
+
+	# Send body piped from stdin to an 'events store' channel
+	cat event.json | kubemqctl events_store send some-channel --body-file -
+
+	# Send body to an 'events store' channel with tags
+	kubemqctl events_store send some-channel some-body --tag source=cli --tag region=eu
 `
 var eventsSendLong = `Send command allows to send (publish) one or many messages to an 'events store' channel`
 var eventsSendShort = `Send messages to an 'events store' channel command`
@@ -56,6 +77,7 @@ func NewCmdEventsStoreSend(ctx context.Context, cfg *config.Config) *cobra.Comma
 		Run: func(cmd *cobra.Command, args []string) {
 			ctx, cancel := context.WithCancel(ctx)
 			defer cancel()
+			o.messagesSet = cmd.Flags().Changed("messages")
 			utils.CheckErr(o.Complete(args, cfg.ConnectionType), cmd)
 			utils.CheckErr(o.Validate())
 			utils.CheckErr(k8s.SetTransport(ctx, cfg))
@@ -65,21 +87,37 @@ func NewCmdEventsStoreSend(ctx context.Context, cfg *config.Config) *cobra.Comma
 	cmd.PersistentFlags().StringVarP(&o.metadata, "metadata", "", "", "set body metadata field")
 	cmd.PersistentFlags().IntVarP(&o.messages, "messages", "m", 1, "set how many 'events store' messages to send")
 	cmd.PersistentFlags().BoolVarP(&o.isStream, "stream", "s", false, "set stream of all messages at once")
-	cmd.PersistentFlags().BoolVarP(&o.fileName, "file", "f", false, "set load body from file")
+	cmd.PersistentFlags().BoolVarP(&o.fileName, "file", "f", false, "set load body from file, file name is set by the body argument")
 	cmd.PersistentFlags().BoolVarP(&o.build, "build", "b", false, "build kubemq targets request")
+	cmd.PersistentFlags().StringVarP(&o.buildMetadata, "build-metadata", "", "", "set kubemq targets request metadata in key1=value1,key2=value2 format")
+	cmd.PersistentFlags().StringVarP(&o.buildData, "build-data", "", "", "set kubemq targets request data file")
+	o.pacing.AddFlags(cmd)
+	o.message.AddFlags(cmd)
+	o.bodyOptions.AddFlags(cmd)
 	return cmd
 }
 
 func (o *EventsStoreSendOptions) Complete(args []string, transport string) error {
 	o.transport = transport
+	if err := o.pacing.Complete(); err != nil {
+		return err
+	}
 	if len(args) >= 1 {
 		o.channel = args[0]
 
 	} else {
 		return fmt.Errorf("missing channel argument")
 	}
+	tmpl, err := o.message.Complete(o.metadata)
+	if err != nil {
+		return err
+	}
+	if tmpl != nil {
+		o.tmpl = tmpl
+		return nil
+	}
 	if o.build {
-		data, err := targets.BuildRequest()
+		data, err := targets.BuildRequest(o.buildMetadata, o.buildData)
 		if err != nil {
 			return err
 		}
@@ -87,22 +125,32 @@ func (o *EventsStoreSendOptions) Complete(args []string, transport string) error
 		return nil
 	}
 	if o.fileName {
-		data, err := targets.BuildFile()
+		fileName := ""
+		if len(args) >= 2 {
+			fileName = args[1]
+		}
+		data, err := targets.BuildFile(fileName)
 		if err != nil {
 			return err
 		}
 		o.body = string(data)
 	} else {
-		if len(args) >= 2 {
-			o.body = args[1]
-		} else {
-			return fmt.Errorf("missing body argument")
+		body, err := o.bodyOptions.Body(args[1:])
+		if err != nil {
+			return err
 		}
+		o.body = string(body)
 	}
 	return nil
 }
 
 func (o *EventsStoreSendOptions) Validate() error {
+	if err := o.pacing.Validate(); err != nil {
+		return err
+	}
+	if o.isStream && o.pacing.IsPaced() {
+		return fmt.Errorf("--stream cannot be set with --rate or --duration")
+	}
 	return nil
 }
 
@@ -116,20 +164,28 @@ func (o *EventsStoreSendOptions) Run(ctx context.Context) error {
 		client.Close()
 	}()
 
+	if o.pacing.IsPaced() {
+		return o.runPaced(ctx, client)
+	}
 	if o.isStream {
 		utils.Printlnf("Streaming %d events store messages...", o.messages)
 		eventsCh := make(chan *kubemq2.EventStore, 1000)
 		eventsResultsCh := make(chan *kubemq2.EventStoreResult, 1000)
 		errCh := make(chan error, 10)
-		fmt.Println("Sending Stream Events Store:")
+		utils.Println("Sending Stream Events Store:")
 		go client.StreamEventsStore(ctx, eventsCh, eventsResultsCh, errCh)
 		startTime := time.Now()
 		for i := 1; i <= o.messages; i++ {
+			m, err := o.render(i - 1)
+			if err != nil {
+				return err
+			}
 			msg := client.ES().
 				SetChannel(o.channel).
 				SetId(uuid.New().String()).
-				SetBody([]byte(o.body)).
-				SetMetadata(o.metadata)
+				SetBody(m.Body).
+				SetMetadata(m.Metadata).
+				SetTags(m.Tags)
 			printEventStore(msg)
 			eventsCh <- msg
 			<-eventsResultsCh
@@ -137,14 +193,19 @@ func (o *EventsStoreSendOptions) Run(ctx context.Context) error {
 		utils.Printlnf("%d events store messages streamed in %s.", o.messages, time.Since(startTime))
 		time.Sleep(2 * time.Second)
 	} else {
-		fmt.Println("Sending Events Store:")
+		utils.Println("Sending Events Store:")
 		for i := 1; i <= o.messages; i++ {
+			m, err := o.render(i - 1)
+			if err != nil {
+				return err
+			}
 			msg := client.ES().
 				SetChannel(o.channel).
 				SetId(uuid.New().String()).
-				SetBody([]byte(o.body)).
-				SetMetadata(o.metadata)
-			_, err := msg.Send(ctx)
+				SetBody(m.Body).
+				SetMetadata(m.Metadata).
+				SetTags(m.Tags)
+			_, err = msg.Send(ctx)
 			if err != nil {
 				return fmt.Errorf("sending 'events store' body, %s", err.Error())
 			}
@@ -154,3 +215,31 @@ func (o *EventsStoreSendOptions) Run(ctx context.Context) error {
 	}
 	return nil
 }
+
+func (o *EventsStoreSendOptions) runPaced(ctx context.Context, client *kubemq2.Client) error {
+	utils.Printlnf("Sending 'events store' messages to %s...", o.channel)
+	return o.pacing.Run(ctx, o.pacing.Count(o.messages, o.messagesSet), func(ctx context.Context, i int) error {
+		m, err := o.render(i)
+		if err != nil {
+			return err
+		}
+		res, err := client.ES().
+			SetChannel(o.channel).
+			SetId(uuid.New().String()).
+			SetBody(m.Body).
+			SetMetadata(m.Metadata).
+			SetTags(m.Tags).
+			Send(ctx)
+		if err != nil {
+			return err
+		}
+		return res.Err
+	}).Report()
+}
+
+func (o *EventsStoreSendOptions) render(i int) (*template.Message, error) {
+	if o.tmpl == nil {
+		return o.message.Message([]byte(o.body), o.metadata), nil
+	}
+	return o.tmpl.Render(o.channel, i)
+}
@@ -14,13 +14,16 @@ import (
 )
 
 type CertsOptions struct {
-	cfg    *config.Config
-	output string
+	cfg     *config.Config
+	outFile string
 }
 
 var certsExamples = `
 	# Execute generate authentication rsa certificates
  	kubemqctl generate auth certs
+
+	# Execute generate authentication rsa certificates and save them to jwt-private.pem and jwt-public.pem
+	kubemqctl generate auth certs --out-file jwt
 `
 var certsLong = `Generate JWT certificates`
 var certsShort = `Generate JWT certificates`
@@ -45,7 +48,7 @@ func NewCmdCerts(ctx context.Context, cfg *config.Config) *cobra.Command {
 		},
 	}
 
-	cmd.PersistentFlags().StringVarP(&o.output, "output", "o", "", "set output filename")
+	cmd.PersistentFlags().StringVarP(&o.outFile, "out-file", "", "", "set output filename")
 	return cmd
 }
 
@@ -58,7 +61,7 @@ func (o *CertsOptions) Validate() error {
 }
 
 func (o *CertsOptions) Run(ctx context.Context) error {
-	return generateCerts(o.output)
+	return generateCerts(o.outFile)
 }
 
 func generateCerts(filePrefix string) error {
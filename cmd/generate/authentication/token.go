@@ -17,8 +17,15 @@ import (
 )
 
 type TokenOptions struct {
-	cfg    *config.Config
-	verify bool
+	cfg            *config.Config
+	verify         bool
+	privateKeyFile string
+	publicKeyFile  string
+	signatureType  string
+	tokenFile      string
+	issuedTo       string
+	expire         string
+	count          int
 }
 
 var tokenExamples = `
@@ -27,6 +34,12 @@ var tokenExamples = `
 
 	# Execute JWT token verification
  	kubemqctl generate auth token -v
+
+	# Execute generate 2 authentication JWT tokens without prompts
+	kubemqctl generate auth token --private-key-file private.pem --signature-type RS512 --issued-to john --expire 48h --count 2 --no-input
+
+	# Execute JWT token verification without prompts
+	kubemqctl generate auth token -v --public-key-file public.pem --signature-type RS512 --token-file token.key --no-input
 `
 var tokenLong = `Generate and validate JWT tokens`
 var tokenShort = `Generate and validate JWT tokens`
@@ -52,6 +65,13 @@ func NewCmdToken(ctx context.Context, cfg *config.Config) *cobra.Command {
 	}
 
 	cmd.PersistentFlags().BoolVarP(&o.verify, "verify", "v", false, "set to verify a token")
+	cmd.PersistentFlags().StringVarP(&o.privateKeyFile, "private-key-file", "", "", "set private key file to sign tokens with")
+	cmd.PersistentFlags().StringVarP(&o.publicKeyFile, "public-key-file", "", "", "set public key file to verify a token with")
+	cmd.PersistentFlags().StringVarP(&o.signatureType, "signature-type", "", "", "set key signature type, one of: HS256|HS384|HS512|RS256|RS384|RS512|ES256|ES384|ES512")
+	cmd.PersistentFlags().StringVarP(&o.tokenFile, "token-file", "", "", "set token file to verify")
+	cmd.PersistentFlags().StringVarP(&o.issuedTo, "issued-to", "", "", "set the name of the token owner")
+	cmd.PersistentFlags().StringVarP(&o.expire, "expire", "", "", "set token expiration time in duration or time formats, i.e 1h or '2022-01-02 15:04:05'")
+	cmd.PersistentFlags().IntVarP(&o.count, "count", "", 0, "set number of tokens to generate")
 	return cmd
 }
 
@@ -69,7 +89,7 @@ func (o *TokenOptions) Run(ctx context.Context) error {
 	return o.generateToken()
 }
 func (o *TokenOptions) verifyToken() error {
-	publicKey, signatureType, err := getPublicKey()
+	publicKey, signatureType, err := o.getPublicKey()
 	if err != nil {
 		return err
 	}
@@ -102,7 +122,7 @@ func (o *TokenOptions) verifyToken() error {
 
 func (o *TokenOptions) generateToken() error {
 
-	privateKey, signatureType, err := getPrivateKey()
+	privateKey, signatureType, err := o.getPrivateKey()
 	if err != nil {
 		return err
 	}
@@ -110,7 +130,7 @@ func (o *TokenOptions) generateToken() error {
 	if err != nil {
 		return err
 	}
-	claims, err := getClaims()
+	claims, err := o.getClaims()
 	if err != nil {
 		return err
 	}
@@ -133,14 +153,22 @@ func (o *TokenOptions) generateToken() error {
 }
 func (o *TokenOptions) getToken() (string, error) {
 	token := ""
-	promptGetToken := &survey.Editor{
-		Message: "Copy & Paste Authentication Token data",
-		Default: "",
-		Help:    "Set Authentication Token data",
-	}
-	err := survey.AskOne(promptGetToken, &token, survey.WithValidator(survey.MinLength(1)))
-	if err != nil {
-		return "", err
+	if o.tokenFile != "" {
+		data, err := ioutil.ReadFile(o.tokenFile)
+		if err != nil {
+			return "", fmt.Errorf("error loading token file: %s", err.Error())
+		}
+		token = string(data)
+	} else {
+		promptGetToken := &survey.Editor{
+			Message: "Copy & Paste Authentication Token data",
+			Default: "",
+			Help:    "Set Authentication Token data",
+		}
+		err := utils.AskOne(promptGetToken, &token, "--token-file flag", survey.WithValidator(survey.MinLength(1)))
+		if err != nil {
+			return "", err
+		}
 	}
 
 	cleaned := strings.Replace(token, " ", "", -1)
@@ -150,50 +178,61 @@ func (o *TokenOptions) getToken() (string, error) {
 
 	return cleaned, nil
 }
-func getClaims() ([]jwt.StandardClaims, error) {
-	issuedTo := ""
-	promptIssuedTo := &survey.Input{
-		Message: "Issue JWT Token to ?",
-		Default: "",
-		Help:    "Set the name of the token owner",
-	}
-	err := survey.AskOne(promptIssuedTo, &issuedTo)
-	if err != nil {
-		return nil, err
+func (o *TokenOptions) getClaims() ([]jwt.StandardClaims, error) {
+	issuedTo := o.issuedTo
+	if issuedTo == "" {
+		promptIssuedTo := &survey.Input{
+			Message: "Issue JWT Token to ?",
+			Default: "",
+			Help:    "Set the name of the token owner",
+		}
+		err := utils.AskOne(promptIssuedTo, &issuedTo, "--issued-to flag")
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	expireAt := &TimeAnswer{}
-	promptExpireAt := &survey.Input{
-		Message: "Set JWT Token Expiration time, i.e 1h or 2022-01-02:",
-		Default: "24h",
-		Help:    "Set JWT token expiration time in duration or time formats",
-	}
-	err = survey.AskOne(promptExpireAt, expireAt, survey.WithValidator(expireAt.Validate))
-	if err != nil {
-		return nil, err
-	}
-	numOfTokens := "1"
-	numOfTokensPrompt := &survey.Input{
-		Message: "Set number of JWT Tokens to generate",
-		Default: "1",
-		Help:    "Set number of JWT Tokens to generate",
-		Suggest: nil,
-	}
-	err = survey.AskOne(numOfTokensPrompt, &numOfTokens, survey.WithValidator(
-		func(ans interface{}) error {
-			val := ans.(string)
-			_, err := strconv.Atoi(val)
-			if err != nil {
-				return err
-			}
-			return nil
-		}))
-	if err != nil {
-		return nil, err
+	if o.expire != "" {
+		if err := expireAt.Validate(o.expire); err != nil {
+			return nil, fmt.Errorf("invalid --expire flag value, %s", err.Error())
+		}
+	} else {
+		promptExpireAt := &survey.Input{
+			Message: "Set JWT Token Expiration time, i.e 1h or 2022-01-02:",
+			Default: "24h",
+			Help:    "Set JWT token expiration time in duration or time formats",
+		}
+		err := utils.AskOne(promptExpireAt, expireAt, "--expire flag", survey.WithValidator(expireAt.Validate))
+		if err != nil {
+			return nil, err
+		}
 	}
-	n, err := strconv.Atoi(numOfTokens)
-	if err != nil {
-		return nil, err
+	n := o.count
+	if n == 0 {
+		numOfTokens := "1"
+		numOfTokensPrompt := &survey.Input{
+			Message: "Set number of JWT Tokens to generate",
+			Default: "1",
+			Help:    "Set number of JWT Tokens to generate",
+			Suggest: nil,
+		}
+		err := utils.AskOne(numOfTokensPrompt, &numOfTokens, "--count flag", survey.WithValidator(
+			func(ans interface{}) error {
+				val := ans.(string)
+				_, err := strconv.Atoi(val)
+				if err != nil {
+					return err
+				}
+				return nil
+			}))
+		if err != nil {
+			return nil, err
+		}
+		n, err = strconv.Atoi(numOfTokens)
+		if err != nil {
+			return nil, err
+		}
 	}
 	if n < 1 {
 		return nil, fmt.Errorf("number of jwt token must have at least 1")
@@ -210,57 +249,68 @@ func getClaims() ([]jwt.StandardClaims, error) {
 			Subject:   "JWT Token",
 		})
 	}
-	return list, err
+	return list, nil
 }
 
-func getPrivateKey() ([]byte, string, error) {
-	privateKey := ""
-	promptPrivateKey := &survey.Editor{
-		Message:  "Copy & Paste Private Key",
-		FileName: "*.pem",
+func (o *TokenOptions) getPrivateKey() ([]byte, string, error) {
+	privateKey, err := o.getKey(o.privateKeyFile, "Copy & Paste Private Key", "--private-key-file flag")
+	if err != nil {
+		return nil, "", err
 	}
-
-	err := survey.AskOne(promptPrivateKey, &privateKey, survey.WithValidator(survey.MinLength(1)))
+	signatureType, err := o.getSignatureType("Select Private Key signature type:", "Select Private Key signature type")
 	if err != nil {
 		return nil, "", err
 	}
-
-	signatureType := ""
-	signatureTypePrompt := &survey.Select{
-		Renderer: survey.Renderer{},
-		Message:  "Select Private Key signature type:",
-		Options:  []string{"HS256", "HS384", "HS512", "RS256", "RS384", "RS512", "ES256", "ES384", "ES512"},
-		Default:  "RS512",
-		Help:     "Select Private Key signature type",
+	return privateKey, signatureType, nil
+}
+func (o *TokenOptions) getPublicKey() ([]byte, string, error) {
+	publicKey, err := o.getKey(o.publicKeyFile, "Copy & Paste Public Key", "--public-key-file flag")
+	if err != nil {
+		return nil, "", err
 	}
-	err = survey.AskOne(signatureTypePrompt, &signatureType)
+	signatureType, err := o.getSignatureType("Select Public Key signature type:", "Select Public Key signature type")
 	if err != nil {
 		return nil, "", err
 	}
-	return []byte(privateKey), signatureType, nil
+	return publicKey, signatureType, nil
 }
-func getPublicKey() ([]byte, string, error) {
-	publicKey := ""
-	promptPublicKey := &survey.Editor{
-		Message:  "Copy & Paste Public Key",
+
+func (o *TokenOptions) getKey(file, message, input string) ([]byte, error) {
+	if file != "" {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("error loading key file: %s", err.Error())
+		}
+		return data, nil
+	}
+	key := ""
+	promptKey := &survey.Editor{
+		Message:  message,
 		FileName: "*.pem",
 	}
-
-	err := survey.AskOne(promptPublicKey, &publicKey, survey.WithValidator(survey.MinLength(1)))
+	err := utils.AskOne(promptKey, &key, input, survey.WithValidator(survey.MinLength(1)))
 	if err != nil {
-		return nil, "", err
+		return nil, err
+	}
+	return []byte(key), nil
+}
+
+func (o *TokenOptions) getSignatureType(message, help string) (string, error) {
+	signatureTypes := []string{"HS256", "HS384", "HS512", "RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}
+	if o.signatureType != "" {
+		return utils.SelectOne(o.signatureType, signatureTypes, message, "--signature-type flag")
 	}
 	signatureType := ""
 	signatureTypePrompt := &survey.Select{
 		Renderer: survey.Renderer{},
-		Message:  "Select Public Key signature type:",
-		Options:  []string{"HS256", "HS384", "HS512", "RS256", "RS384", "RS512", "ES256", "ES384", "ES512"},
+		Message:  message,
+		Options:  signatureTypes,
 		Default:  "RS512",
-		Help:     "Select Public Key signature type",
+		Help:     help,
 	}
-	err = survey.AskOne(signatureTypePrompt, &signatureType)
+	err := utils.AskOne(signatureTypePrompt, &signatureType, "--signature-type flag")
 	if err != nil {
-		return nil, "", err
+		return "", err
 	}
-	return []byte(publicKey), signatureType, nil
+	return signatureType, nil
 }
@@ -12,12 +12,16 @@ import (
 )
 
 type AuthorizationOptions struct {
-	cfg *config.Config
+	cfg   *config.Config
+	rules []string
 }
 
 var authorizationExamples = `
 	# Execute generate authorization policy file
  	kubemqctl generate az
+
+	# Execute generate authorization policy file without prompts
+	kubemqctl generate az --rule "client_id=.*,channel=orders.*,resources=queues;events,actions=read;write"
 `
 var authorizationLong = `Generate authorization policy file`
 var authorizationShort = `Generate authorization policy file`
@@ -40,7 +44,7 @@ func NewCmdAuthorization(ctx context.Context, cfg *config.Config) *cobra.Command
 			utils.CheckErr(o.Run(ctx))
 		},
 	}
-
+	cmd.PersistentFlags().StringArrayVarP(&o.rules, "rule", "", []string{}, "set rule in client_id=...,channel=...,resources=queues;events;events_store;queries;commands,actions=read;write format, can be set multiple times")
 	return cmd
 }
 
@@ -53,6 +57,16 @@ func (o *AuthorizationOptions) Validate() error {
 }
 func (o *AuthorizationOptions) Run(ctx context.Context) error {
 	var rules []*Rule
+	if len(o.rules) > 0 {
+		for _, value := range o.rules {
+			r, err := parseRule(value)
+			if err != nil {
+				return err
+			}
+			rules = append(rules, r)
+		}
+		goto save
+	}
 	utils.Println("Create first rule:")
 	for {
 		r, err := getRule()
@@ -66,7 +80,7 @@ func (o *AuthorizationOptions) Run(ctx context.Context) error {
 			Default: true,
 			Help:    "",
 		}
-		err = survey.AskOne(addMorePrompt, &addMoreRule)
+		err = utils.AskOne(addMorePrompt, &addMoreRule, "--rule flag")
 		if err != nil {
 			return err
 		}
@@ -1,7 +1,10 @@
 package authorization
 
 import (
+	"fmt"
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/kubemq-io/kubemqctl/pkg/utils"
+	"strings"
 )
 
 type Rule struct {
@@ -63,22 +66,78 @@ func getRule() (*Rule, error) {
 			},
 		},
 	}
-	err := survey.Ask(qs, &answers)
+	err := utils.Ask(qs, &answers, "--rule flag")
 	if err != nil {
 		return nil, err
 	}
+	return newRule(answers.ClientID, answers.Channel, answers.Resources, answers.Actions), nil
+}
+
+// parseRule parses a rule flag value in client_id=...,channel=...,resources=...,actions=... format, resources and actions are ';' separated
+func parseRule(value string) (*Rule, error) {
+	clientID, channel := ".*", ".*"
+	var resources, actions []string
+	for _, field := range strings.Split(value, ",") {
+		kv := strings.SplitN(field, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid rule %s, field %s must be in key=value format", value, field)
+		}
+		switch strings.TrimSpace(kv[0]) {
+		case "client_id":
+			clientID = kv[1]
+		case "channel":
+			channel = kv[1]
+		case "resources":
+			for _, resource := range strings.Split(kv[1], ";") {
+				switch strings.ToLower(strings.TrimSpace(resource)) {
+				case "queues":
+					resources = append(resources, "Queues")
+				case "events":
+					resources = append(resources, "Events")
+				case "events_store":
+					resources = append(resources, "Events Store")
+				case "queries":
+					resources = append(resources, "Queries")
+				case "commands":
+					resources = append(resources, "Commands")
+				default:
+					return nil, fmt.Errorf("invalid rule %s, resource must be queues, events, events_store, queries or commands", value)
+				}
+			}
+		case "actions":
+			for _, action := range strings.Split(kv[1], ";") {
+				switch strings.ToLower(strings.TrimSpace(action)) {
+				case "read":
+					actions = append(actions, "Read")
+				case "write":
+					actions = append(actions, "Write")
+				default:
+					return nil, fmt.Errorf("invalid rule %s, action must be read or write", value)
+				}
+			}
+		default:
+			return nil, fmt.Errorf("invalid rule %s, unknown field %s", value, kv[0])
+		}
+	}
+	if clientID == "" || channel == "" {
+		return nil, fmt.Errorf("invalid rule %s, client_id and channel cannot be empty", value)
+	}
+	return newRule(clientID, channel, resources, actions), nil
+}
+
+func newRule(clientID, channel string, resources, actions []string) *Rule {
 	r := &Rule{
-		ClientID:    answers.ClientID,
+		ClientID:    clientID,
 		Events:      false,
 		EventsStore: false,
 		Queues:      false,
 		Commands:    false,
 		Queries:     false,
-		Channel:     answers.Channel,
+		Channel:     channel,
 		Read:        false,
 		Write:       false,
 	}
-	for _, resource := range answers.Resources {
+	for _, resource := range resources {
 		switch resource {
 		case "Queues":
 			r.Queues = true
@@ -96,7 +155,7 @@ func getRule() (*Rule, error) {
 			r.Commands = true
 		}
 	}
-	for _, action := range answers.Actions {
+	for _, action := range actions {
 		switch action {
 		case "Read":
 			r.Read = true
@@ -104,5 +163,5 @@ func getRule() (*Rule, error) {
 			r.Write = true
 		}
 	}
-	return r, nil
+	return r
 }
@@ -3,6 +3,7 @@ package routing
 import (
 	"fmt"
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/kubemq-io/kubemqctl/pkg/utils"
 	"strings"
 )
 
@@ -33,30 +34,49 @@ func getRoute() (*Route, error) {
 				Suggest: nil,
 			},
 			Validate: func(ans interface{}) error {
-				val := ans.(string)
-				routes := strings.Split(val, ";")
-				if len(routes) == 0 {
-					return fmt.Errorf("routes must have at least one route")
-				}
-				for _, route := range routes {
-					kv := strings.Split(route, ":")
-					if len(kv) != 2 {
-						return fmt.Errorf("single route must have a channel value")
-					}
-					switch kv[0] {
-					case "queues", "events", "events_store", "routes":
-					default:
-						return fmt.Errorf("route must start with 'queues' or 'events' or 'events_store")
-					}
-				}
-				return nil
+				return validateRoutes(ans.(string))
 			},
 		},
 	}
-	err := survey.Ask(qs, r)
+	err := utils.Ask(qs, r, "--route flag")
 	if err != nil {
 		return nil, err
 	}
 
 	return r, nil
 }
+
+// parseRoute parses a route flag value in key=routes format
+func parseRoute(value string) (*Route, error) {
+	idx := strings.Index(value, "=")
+	if idx <= 0 {
+		return nil, fmt.Errorf("invalid route %s, must be in key=routes format", value)
+	}
+	r := &Route{
+		Key:    value[:idx],
+		Routes: value[idx+1:],
+	}
+	if err := validateRoutes(r.Routes); err != nil {
+		return nil, fmt.Errorf("invalid route %s, %s", value, err.Error())
+	}
+	return r, nil
+}
+
+func validateRoutes(val string) error {
+	routes := strings.Split(val, ";")
+	if len(routes) == 0 {
+		return fmt.Errorf("routes must have at least one route")
+	}
+	for _, route := range routes {
+		kv := strings.Split(route, ":")
+		if len(kv) != 2 {
+			return fmt.Errorf("single route must have a channel value")
+		}
+		switch kv[0] {
+		case "queues", "events", "events_store", "routes":
+		default:
+			return fmt.Errorf("route must start with 'queues' or 'events' or 'events_store")
+		}
+	}
+	return nil
+}
@@ -14,11 +14,15 @@ import (
 type RoutingOptions struct {
 	cfg    *config.Config
 	verify bool
+	routes []string
 }
 
 var policyExamples = `
 	# Execute generate smart routing file
  	kubemqctl generate routes
+
+	# Execute generate smart routing file without prompts
+	kubemqctl generate routes --route "key1=queues:foo.bar;events:baz.foo" --route "key2=events_store:foo"
 `
 var policyLong = `Generate KubeMQ Smart Routing file`
 var policyShort = `Generate KubeMQ Smart Routing file`
@@ -41,7 +45,7 @@ func NewCmdRouting(ctx context.Context, cfg *config.Config) *cobra.Command {
 			utils.CheckErr(o.Run(ctx))
 		},
 	}
-
+	cmd.PersistentFlags().StringArrayVarP(&o.routes, "route", "", []string{}, "set route in key=routes format, i.e. key1=queues:foo.bar;events:baz.foo, can be set multiple times")
 	return cmd
 }
 
@@ -54,6 +58,16 @@ func (o *RoutingOptions) Validate() error {
 }
 func (o *RoutingOptions) Run(ctx context.Context) error {
 	var routes []*Route
+	if len(o.routes) > 0 {
+		for _, value := range o.routes {
+			r, err := parseRoute(value)
+			if err != nil {
+				return err
+			}
+			routes = append(routes, r)
+		}
+		goto save
+	}
 	utils.Println("Create first route:")
 	for {
 		r, err := getRoute()
@@ -67,7 +81,7 @@ func (o *RoutingOptions) Run(ctx context.Context) error {
 			Default: true,
 			Help:    "",
 		}
-		err = survey.AskOne(addMorePrompt, &addMoreRule)
+		err = utils.AskOne(addMorePrompt, &addMoreRule, "--route flag")
 		if err != nil {
 			return err
 		}
@@ -9,6 +9,7 @@ import (
 	"github.com/kubemq-io/kubemqctl/pkg/config"
 	"github.com/kubemq-io/kubemqctl/pkg/k8s/client"
 	"github.com/kubemq-io/kubemqctl/pkg/k8s/manager/cluster"
+	"github.com/kubemq-io/kubemqctl/pkg/output"
 	"github.com/kubemq-io/kubemqctl/pkg/utils"
 	"github.com/spf13/cobra"
 	"os"
@@ -78,14 +79,39 @@ func (o *getOptions) Run(ctx context.Context) error {
 	if len(clusters.List()) == 0 {
 		return fmt.Errorf("no Kubemq clusters were found")
 	}
+	if !output.IsText() {
+		return output.Print(clusters.Items())
+	}
 	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
-	fmt.Fprintf(w, "NAME\tDESIRED\tREADY\tIMAGE\tGRPC\tREST\tAPI\tLICENSE-TO\tLICENSE-TYPE\tLICENSE-EXPIRE\n")
+	if output.IsWide() {
+		fmt.Fprintf(w, "NAME\tNAMESPACE\tDESIRED\tREADY\tSTATUS\tIMAGE\tGRPC\tREST\tAPI\tSELECTOR\tLICENSE-TO\tLICENSE-TYPE\tLICENSE-EXPIRE\n")
+	} else {
+		fmt.Fprintf(w, "NAME\tDESIRED\tREADY\tIMAGE\tGRPC\tREST\tAPI\tLICENSE-TO\tLICENSE-TYPE\tLICENSE-EXPIRE\n")
+	}
 	for _, name := range clusters.List() {
 		cluster := clusters.Cluster(name)
 		var replicas int32
 		if cluster.Status.Replicas != nil {
 			replicas = *cluster.Status.Replicas
 		}
+		if output.IsWide() {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				name,
+				cluster.Namespace,
+				replicas,
+				cluster.Status.Ready,
+				cluster.Status.Status,
+				cluster.Status.Version,
+				cluster.Status.Grpc,
+				cluster.Status.Rest,
+				cluster.Status.Api,
+				cluster.Status.Selector,
+				cluster.Status.LicenseTo,
+				cluster.Status.LicenseType,
+				cluster.Status.LicenseExpire,
+			)
+			continue
+		}
 		fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
 			name,
 			replicas,
@@ -3,7 +3,6 @@ package describe
 import (
 	"context"
 	"fmt"
-	"github.com/AlecAivazis/survey/v2"
 	"github.com/kubemq-io/kubemqctl/pkg/config"
 	"github.com/kubemq-io/kubemqctl/pkg/k8s/client"
 	"github.com/kubemq-io/kubemqctl/pkg/k8s/manager/cluster"
@@ -12,12 +11,16 @@ import (
 )
 
 type DescribeOptions struct {
-	cfg *config.Config
+	cfg     *config.Config
+	cluster string
 }
 
 var describeExamples = `
 	# Describe Kubemq cluster to console
 	kubemqctl get cluster describe
+
+	# Describe Kubemq cluster kubemq/kubemq-cluster to console
+	kubemqctl get cluster describe --cluster kubemq/kubemq-cluster
 `
 var describeLong = `Describe command allows describing a Kubemq cluster to console`
 var describeShort = `Describe Kubemq cluster command`
@@ -41,7 +44,7 @@ func NewCmdDescribe(ctx context.Context, cfg *config.Config) *cobra.Command {
 			utils.CheckErr(o.Run(ctx))
 		},
 	}
-
+	cmd.PersistentFlags().StringVarP(&o.cluster, "cluster", "", "", "set Kubemq cluster to describe, i.e. namespace/name")
 	return cmd
 }
 
@@ -72,25 +75,9 @@ func (o *DescribeOptions) Run(ctx context.Context) error {
 		return fmt.Errorf("no Kubemq clusters were found to describe")
 	}
 
-	selection := ""
-	if len(clusters.List()) == 1 {
-		selection = clusters.List()[0]
-	} else {
-		selected := &survey.Select{
-			Renderer:      survey.Renderer{},
-			Message:       "Select Kubemq cluster to describe",
-			Options:       clusters.List(),
-			Default:       clusters.List()[0],
-			PageSize:      0,
-			VimMode:       false,
-			FilterMessage: "",
-			Filter:        nil,
-		}
-		err = survey.AskOne(selected, &selection)
-		if err != nil {
-			return err
-		}
-
+	selection, err := utils.SelectOne(o.cluster, clusters.List(), "Select Kubemq cluster to describe", "--cluster flag")
+	if err != nil {
+		return err
 	}
 
 	spec := clusters.Cluster(selection)
@@ -3,7 +3,6 @@ package events
 import (
 	"context"
 	"fmt"
-	"github.com/AlecAivazis/survey/v2"
 	"github.com/kubemq-io/kubemqctl/pkg/config"
 	"github.com/kubemq-io/kubemqctl/pkg/k8s/client"
 	"github.com/kubemq-io/kubemqctl/pkg/k8s/manager/cluster"
@@ -79,25 +78,9 @@ func (o *EventsOptions) Run(ctx context.Context) error {
 			return fmt.Errorf("no Kubemq clusters were found to show events")
 		}
 
-		selection := ""
-		if len(clusters.List()) == 1 {
-			selection = clusters.List()[0]
-		} else {
-			selected := &survey.Select{
-				Renderer:      survey.Renderer{},
-				Message:       "Select Kubemq cluster to show events",
-				Options:       clusters.List(),
-				Default:       clusters.List()[0],
-				PageSize:      0,
-				VimMode:       false,
-				FilterMessage: "",
-				Filter:        nil,
-			}
-			err = survey.AskOne(selected, &selection)
-			if err != nil {
-				return err
-			}
-
+		selection, err := utils.SelectOne("", clusters.List(), "Select Kubemq cluster to show events", "--cluster flag")
+		if err != nil {
+			return err
 		}
 		pair = strings.Split(selection, "/")
 	} else {
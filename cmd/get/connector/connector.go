@@ -8,6 +8,7 @@ import (
 	"github.com/kubemq-io/kubemqctl/pkg/config"
 	"github.com/kubemq-io/kubemqctl/pkg/k8s/client"
 	"github.com/kubemq-io/kubemqctl/pkg/k8s/manager/connector"
+	"github.com/kubemq-io/kubemqctl/pkg/output"
 	"github.com/kubemq-io/kubemqctl/pkg/utils"
 	"github.com/spf13/cobra"
 	"os"
@@ -76,10 +77,32 @@ func (o *getOptions) Run(ctx context.Context) error {
 	if len(connectors.List()) == 0 {
 		return fmt.Errorf("no Kubemq connectors were found")
 	}
+	if !output.IsText() {
+		return output.Print(connectors.Items())
+	}
 	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
-	fmt.Fprintf(w, "NAME\tNAMESPACE\tREPLICAS\tTYPE\tIMAGE\tAPI\tSTATUS\t\n")
+	if output.IsWide() {
+		fmt.Fprintf(w, "NAME\tNAMESPACE\tREPLICAS\tTYPE\tIMAGE\tAPI\tSTATUS\tSERVICE-TYPE\tNODE-PORT\tCREATED\t\n")
+	} else {
+		fmt.Fprintf(w, "NAME\tNAMESPACE\tREPLICAS\tTYPE\tIMAGE\tAPI\tSTATUS\t\n")
+	}
 	for _, name := range connectors.List() {
 		connector := connectors.Connector(name)
+		if output.IsWide() {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\t%s\t%s\t%d\t%s\n",
+				name,
+				connector.Namespace,
+				connector.Status.Replicas,
+				connector.Status.Type,
+				connector.Status.Image,
+				connector.Status.Api,
+				connector.Status.Status,
+				connector.Spec.ServiceType,
+				connector.Spec.NodePort,
+				connector.CreationTimestamp.Format("2006-01-02 15:04:05"),
+			)
+			continue
+		}
 		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\t%s\n",
 			name,
 			connector.Namespace,
@@ -3,7 +3,6 @@ package describe
 import (
 	"context"
 	"fmt"
-	"github.com/AlecAivazis/survey/v2"
 	"github.com/kubemq-io/kubemqctl/pkg/config"
 	"github.com/kubemq-io/kubemqctl/pkg/k8s/client"
 	"github.com/kubemq-io/kubemqctl/pkg/k8s/manager/connector"
@@ -12,12 +11,16 @@ import (
 )
 
 type DescribeOptions struct {
-	cfg *config.Config
+	cfg       *config.Config
+	connector string
 }
 
 var describeExamples = `
 	# Describe Kubemq connector to console
 	kubemqctl get connector describe
+
+	# Describe Kubemq connector kubemq/kubemq-connector to console
+	kubemqctl get connector describe --connector kubemq/kubemq-connector
 `
 var describeLong = `Describe command allows describing a Kubemq connector to console`
 var describeShort = `Describe Kubemq connector command`
@@ -41,7 +44,7 @@ func NewCmdDescribe(ctx context.Context, cfg *config.Config) *cobra.Command {
 			utils.CheckErr(o.Run(ctx))
 		},
 	}
-
+	cmd.PersistentFlags().StringVarP(&o.connector, "connector", "", "", "set Kubemq connector to describe, i.e. namespace/name")
 	return cmd
 }
 
@@ -72,25 +75,9 @@ func (o *DescribeOptions) Run(ctx context.Context) error {
 		return fmt.Errorf("no Kubemq connectors were found to describe")
 	}
 
-	selection := ""
-	if len(connectors.List()) == 1 {
-		selection = connectors.List()[0]
-	} else {
-		selected := &survey.Select{
-			Renderer:      survey.Renderer{},
-			Message:       "Select Kubemq connector to describe",
-			Options:       connectors.List(),
-			Default:       connectors.List()[0],
-			PageSize:      0,
-			VimMode:       false,
-			FilterMessage: "",
-			Filter:        nil,
-		}
-		err = survey.AskOne(selected, &selection)
-		if err != nil {
-			return err
-		}
-
+	selection, err := utils.SelectOne(o.connector, connectors.List(), "Select Kubemq connector to describe", "--connector flag")
+	if err != nil {
+		return err
 	}
 
 	spec := connectors.Connector(selection)
@@ -2,7 +2,6 @@ package logs
 
 import (
 	"context"
-	"github.com/AlecAivazis/survey/v2"
 	"github.com/kubemq-io/kubemqctl/pkg/k8s/manager/connector"
 	"strings"
 
@@ -19,6 +18,7 @@ type LogsOptions struct {
 	cfg *config.Config
 	*logs.Options
 	disableColor bool
+	connector    string
 }
 
 var logsExamples = `
@@ -39,6 +39,9 @@ var logsExamples = `
 
 	# Stream logs of specific container
 	kubemqctl get connector logs -c kubemq-connector-0
+
+	# Stream logs of Kubemq connector kubemq/kubemq-connector without selection
+	kubemqctl get connector logs --connector kubemq/kubemq-connector
 `
 var logsLong = `Logs command allows to stream pods logs with powerful filtering capabilities`
 var logsShort = `Stream logs of Kubemq connector pods command`
@@ -83,6 +86,7 @@ func NewCmdLogs(ctx context.Context, cfg *config.Config) *cobra.Command {
 	cmd.PersistentFlags().StringVarP(&o.Options.Selector, "label", "l", "", "Set label selector")
 	cmd.PersistentFlags().Int64VarP(&o.Options.Tail, "tail", "t", 0, "Set how many lines to tail for each pod")
 	cmd.PersistentFlags().BoolVarP(&o.disableColor, "disable-color", "", false, "Set to disable colorized output")
+	cmd.PersistentFlags().StringVarP(&o.connector, "connector", "", "", "Set Kubemq connector to show logs for, i.e. namespace/name")
 
 	return cmd
 }
@@ -105,14 +109,7 @@ func (o *LogsOptions) Complete(args []string) error {
 		if len(connectors.List()) == 0 {
 			goto NEXT
 		}
-		selection := ""
-		prompt := &survey.Select{
-			Renderer: survey.Renderer{},
-			Message:  "Show logs for Kubemq connectors:",
-			Options:  connectors.List(),
-			Default:  connectors.List()[0],
-		}
-		err = survey.AskOne(prompt, &selection)
+		selection, err := utils.SelectOne(o.connector, connectors.List(), "Show logs for Kubemq connectors:", "pod query argument or --connector flag")
 		if err != nil {
 			return err
 		}
@@ -3,7 +3,6 @@ package dashboard
 import (
 	"context"
 	"fmt"
-	"github.com/AlecAivazis/survey/v2"
 	"github.com/kubemq-io/kubemqctl/pkg/config"
 	"github.com/kubemq-io/kubemqctl/pkg/k8s"
 	"github.com/kubemq-io/kubemqctl/pkg/k8s/client"
@@ -15,12 +14,16 @@ import (
 )
 
 type getOptions struct {
-	cfg *config.Config
+	cfg     *config.Config
+	cluster string
 }
 
 var getExamples = `
 	# Get KubeMQ web interface
 	kubemqctl get dashboard
+
+	# Get KubeMQ web interface of kubemq/kubemq-cluster
+	kubemqctl get dashboard --cluster kubemq/kubemq-cluster
 `
 var getLong = `Get access to KubeMQ dashboard`
 var getShort = `Get access to KubeMQ dashboard`
@@ -44,6 +47,7 @@ func NewCmdGet(ctx context.Context, cfg *config.Config) *cobra.Command {
 			utils.CheckErr(o.Run(ctx))
 		},
 	}
+	cmd.PersistentFlags().StringVarP(&o.cluster, "cluster", "", "", "set KubeMQ cluster to show dashboard for, i.e. namespace/name")
 	return cmd
 }
 
@@ -76,25 +80,11 @@ func (o *getOptions) Run(ctx context.Context) error {
 	if len(clusters.List()) == 0 {
 		return fmt.Errorf("no Kubemq clusters were found")
 	}
-	if len(clusters.List()) == 1 {
-		pair := clusters.List()[0]
-		namespace, clusterName = StringSplit(pair)
-	} else {
-		selection := ""
-		prompt := &survey.Select{
-			Renderer: survey.Renderer{},
-			Message:  "Show Dashboard for KubeMQ cluster:",
-			Options:  clusters.List(),
-			Default:  clusters.List()[0],
-		}
-		err = survey.AskOne(prompt, &selection)
-		if err != nil {
-			return err
-		}
-		pair := strings.Split(selection, "/")
-		namespace = pair[0]
-		clusterName = pair[1]
+	selection, err := utils.SelectOne(o.cluster, clusters.List(), "Show Dashboard for KubeMQ cluster:", "--cluster flag")
+	if err != nil {
+		return err
 	}
+	namespace, clusterName = StringSplit(selection)
 
 	fmt.Printf("Opening Dashboard for Kubemq cluster: %s/%s\n", namespace, clusterName)
 	proxyOptions := &k8s.ProxyOptions{
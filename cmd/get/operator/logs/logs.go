@@ -4,7 +4,6 @@ import (
 	"context"
 	"strings"
 
-	"github.com/AlecAivazis/survey/v2"
 	"github.com/kubemq-io/kubemqctl/pkg/k8s/manager/operator"
 
 	"github.com/kubemq-io/kubemqctl/pkg/config"
@@ -20,6 +19,7 @@ type LogsOptions struct {
 	cfg *config.Config
 	*logs.Options
 	disableColor bool
+	operator     string
 }
 
 var logsExamples = `
@@ -40,6 +40,9 @@ var logsExamples = `
 
 	# Stream logs of specific container
 	kubemqctl get operator logs -c kubemq-operator-0
+
+	# Stream logs of Kubemq operator kubemq/kubemq-operator without selection
+	kubemqctl get operator logs --operator kubemq/kubemq-operator
 `
 
 var (
@@ -86,6 +89,7 @@ func NewCmdLogs(ctx context.Context, cfg *config.Config) *cobra.Command {
 	cmd.PersistentFlags().StringVarP(&o.Options.Selector, "label", "l", "", "Set label selector")
 	cmd.PersistentFlags().Int64VarP(&o.Options.Tail, "tail", "t", 0, "Set how many lines to tail for each pod")
 	cmd.PersistentFlags().BoolVarP(&o.disableColor, "disable-color", "", false, "Set to disable colorized output")
+	cmd.PersistentFlags().StringVarP(&o.operator, "operator", "", "", "Set Kubemq operator to show logs for, i.e. namespace/name")
 
 	return cmd
 }
@@ -108,14 +112,7 @@ func (o *LogsOptions) Complete(args []string) error {
 		if len(operators.List()) == 0 {
 			goto NEXT
 		}
-		selection := ""
-		prompt := &survey.Select{
-			Renderer: survey.Renderer{},
-			Message:  "Show logs for Kubemq operator:",
-			Options:  operators.List(),
-			Default:  operators.List()[0],
-		}
-		err = survey.AskOne(prompt, &selection)
+		selection, err := utils.SelectOne(o.operator, operators.List(), "Show logs for Kubemq operator:", "pod query argument or --operator flag")
 		if err != nil {
 			return err
 		}
@@ -7,6 +7,7 @@ import (
 	"github.com/kubemq-io/kubemqctl/pkg/config"
 	"github.com/kubemq-io/kubemqctl/pkg/k8s/client"
 	"github.com/kubemq-io/kubemqctl/pkg/k8s/manager/operator"
+	"github.com/kubemq-io/kubemqctl/pkg/output"
 	"github.com/kubemq-io/kubemqctl/pkg/utils"
 	"github.com/spf13/cobra"
 	"os"
@@ -76,9 +77,35 @@ func (o *GetOptions) Run(ctx context.Context) error {
 	if len(operators) == 0 {
 		return fmt.Errorf("no Kubemq operators were found in the cluster")
 	}
+	if !output.IsText() {
+		return output.Print(operators)
+	}
 	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
-	fmt.Fprintf(w, "NAME\tNAMSPACE\n")
+	if output.IsWide() {
+		fmt.Fprintf(w, "NAME\tNAMSPACE\tREADY\tIMAGE\tCREATED\n")
+	} else {
+		fmt.Fprintf(w, "NAME\tNAMSPACE\n")
+	}
 	for _, item := range operators {
+		if output.IsWide() {
+			image := ""
+			if len(item.Spec.Template.Spec.Containers) > 0 {
+				image = item.Spec.Template.Spec.Containers[0].Image
+			}
+			var replicas int32
+			if item.Spec.Replicas != nil {
+				replicas = *item.Spec.Replicas
+			}
+			fmt.Fprintf(w, "%s\t%s\t%d/%d\t%s\t%s\n",
+				item.Name,
+				item.Namespace,
+				item.Status.ReadyReplicas,
+				replicas,
+				image,
+				item.CreationTimestamp.Format("2006-01-02 15:04:05"),
+			)
+			continue
+		}
 		fmt.Fprintf(w, "%s\t%s\n",
 			item.Name,
 			item.Namespace,
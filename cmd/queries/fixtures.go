@@ -0,0 +1,152 @@
+package queries
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/ghodss/yaml"
+	kubemq "github.com/kubemq-io/kubemq-go"
+	"github.com/kubemq-io/kubemqctl/pkg/match"
+)
+
+type fixtureResponse struct {
+	Body     string            `json:"body"`
+	Metadata string            `json:"metadata"`
+	Tags     map[string]string `json:"tags"`
+	Delay    string            `json:"delay"`
+	Error    string            `json:"error"`
+	delay    time.Duration
+}
+
+type fixtureRule struct {
+	Name     string           `json:"name"`
+	Match    match.Matcher    `json:"match"`
+	Response *fixtureResponse `json:"response"`
+}
+
+// fixtures is a queries mock rule table, the first matching rule responses the query, unmatched queries get the default response, or an error response when no default is set
+type fixtures struct {
+	Rules   []*fixtureRule   `json:"rules"`
+	Default *fixtureResponse `json:"default"`
+}
+
+func loadFixtures(fileName string) (*fixtures, error) {
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	f := &fixtures{}
+	if err := yaml.Unmarshal(data, f); err != nil {
+		return nil, fmt.Errorf("invalid fixtures file, %s", err.Error())
+	}
+	if err := f.compile(); err != nil {
+		return nil, fmt.Errorf("invalid fixtures file, %s", err.Error())
+	}
+	return f, nil
+}
+
+func (f *fixtures) compile() error {
+	for i, rule := range f.Rules {
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("rule-%d", i+1)
+		}
+		if err := rule.Match.Compile(); err != nil {
+			return fmt.Errorf("rule %s, %s", rule.Name, err.Error())
+		}
+		if rule.Response == nil {
+			return fmt.Errorf("rule %s, missing response", rule.Name)
+		}
+		if err := rule.Response.compile(); err != nil {
+			return fmt.Errorf("rule %s, %s", rule.Name, err.Error())
+		}
+	}
+	if f.Default != nil {
+		if err := f.Default.compile(); err != nil {
+			return fmt.Errorf("default response, %s", err.Error())
+		}
+	}
+	return nil
+}
+
+func (r *fixtureResponse) compile() error {
+	if r.Delay == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(r.Delay)
+	if err != nil {
+		return fmt.Errorf("invalid delay %s, %s", r.Delay, err.Error())
+	}
+	r.delay = d
+	return nil
+}
+
+// find returns the first rule which matches the query, or nil when no rule matches
+func (f *fixtures) find(query *kubemq.QueryReceive) *fixtureRule {
+	for _, rule := range f.Rules {
+		if rule.Match.Match(query.Metadata, query.Tags, query.Body) {
+			return rule
+		}
+	}
+	return nil
+}
+
+type fixtureHit struct {
+	Rule string `json:"rule"`
+	Hits int    `json:"hits"`
+}
+
+// fixtureHits counts the queries responded by each rule, rules are reported in the order they were loaded, including rules removed by a reload
+type fixtureHits struct {
+	sync.Mutex
+	rules     []*fixtureHit
+	index     map[string]*fixtureHit
+	defaults  int
+	unmatched int
+}
+
+func newFixtureHits() *fixtureHits {
+	return &fixtureHits{
+		index: map[string]*fixtureHit{},
+	}
+}
+
+func (h *fixtureHits) register(f *fixtures) {
+	h.Lock()
+	defer h.Unlock()
+	for _, rule := range f.Rules {
+		if _, ok := h.index[rule.Name]; !ok {
+			hit := &fixtureHit{Rule: rule.Name}
+			h.index[rule.Name] = hit
+			h.rules = append(h.rules, hit)
+		}
+	}
+}
+
+func (h *fixtureHits) hit(rule *fixtureRule, isDefault bool) {
+	h.Lock()
+	defer h.Unlock()
+	switch {
+	case rule != nil:
+		h.index[rule.Name].Hits++
+	case isDefault:
+		h.defaults++
+	default:
+		h.unmatched++
+	}
+}
+
+func (h *fixtureHits) report() []*fixtureHit {
+	h.Lock()
+	defer h.Unlock()
+	var list []*fixtureHit
+	for _, hit := range h.rules {
+		list = append(list, &fixtureHit{Rule: hit.Rule, Hits: hit.Hits})
+	}
+	list = append(list,
+		&fixtureHit{Rule: "(default)", Hits: h.defaults},
+		&fixtureHit{Rule: "(unmatched)", Hits: h.unmatched},
+	)
+	return list
+}
@@ -0,0 +1,280 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	kubemq2 "github.com/kubemq-io/kubemq-go"
+	"github.com/kubemq-io/kubemqctl/pkg/config"
+	"github.com/kubemq-io/kubemqctl/pkg/k8s"
+	"github.com/kubemq-io/kubemqctl/pkg/kubemq"
+	"github.com/kubemq-io/kubemqctl/pkg/output"
+	"github.com/kubemq-io/kubemqctl/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+type QueriesMockOptions struct {
+	cfg          *config.Config
+	transport    string
+	channel      string
+	group        string
+	fixturesFile string
+	reload       time.Duration
+	reconnect    bool
+	mu           sync.RWMutex
+	fixtures     *fixtures
+	modTime      time.Time
+	hits         *fixtureHits
+}
+
+var queriesMockExamples = `
+	# Mock a query service on a 'queries' channel, each query is responded by the first matching rule of fixtures.yaml
+	kubemqctl queries mock some-channel --fixtures fixtures.yaml
+
+	# Fixtures file example, rules match on metadata, tags, body regex and json body fields (dot separated paths)
+	rules:
+	  - name: get-order
+	    match:
+	      metadata: get-order
+	      json:
+	        order.type: express
+	    response:
+	      body: '{"status":"shipped"}'
+	      metadata: ok
+	      tags:
+	        source: mock
+	      delay: 200ms
+	  - name: bad-request
+	    match:
+	      body: '^$'
+	    response:
+	      error: empty query
+	default:
+	  body: '{}'
+`
+var queriesMockLong = `Mock command allows to respond the queries of a 'queries' channel from a fixtures rule table. The fixtures file is reloaded when it changes, and a report of the hits of each rule is printed on exit`
+var queriesMockShort = `Mock a 'queries' channel responder from a fixtures file command`
+
+func NewCmdQueriesMock(ctx context.Context, cfg *config.Config) *cobra.Command {
+	o := &QueriesMockOptions{
+		cfg:  cfg,
+		hits: newFixtureHits(),
+	}
+	cmd := &cobra.Command{
+
+		Use:     "mock",
+		Aliases: []string{"m"},
+		Short:   queriesMockShort,
+		Long:    queriesMockLong,
+		Example: queriesMockExamples,
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			utils.CheckErr(o.Complete(args, cfg.ConnectionType), cmd)
+			utils.CheckErr(o.Validate())
+			utils.CheckErr(k8s.SetTransport(ctx, cfg))
+			utils.CheckErr(o.Run(ctx))
+		},
+	}
+	cmd.PersistentFlags().StringVarP(&o.fixturesFile, "fixtures", "f", "", "set fixtures rules file (yaml or json)")
+	cmd.PersistentFlags().StringVarP(&o.group, "group", "g", "", "set 'queries' channel consumer group (load balancing)")
+	cmd.PersistentFlags().DurationVarP(&o.reload, "reload-interval", "", time.Second, "set how often to check the fixtures file for changes, 0 disables hot reload")
+	cmd.PersistentFlags().BoolVarP(&o.reconnect, "reconnect", "", true, "set auto reconnect with exponential backoff when the subscription disconnects")
+	return cmd
+}
+
+func (o *QueriesMockOptions) Complete(args []string, transport string) error {
+	o.transport = transport
+	if len(args) >= 1 {
+		o.channel = args[0]
+	} else {
+		return fmt.Errorf("missing channel argument")
+	}
+	if o.fixturesFile == "" {
+		return fmt.Errorf("missing --fixtures file")
+	}
+	return o.load()
+}
+
+func (o *QueriesMockOptions) Validate() error {
+	if o.reload < 0 {
+		return fmt.Errorf("reload interval cannot be negative")
+	}
+	return nil
+}
+
+func (o *QueriesMockOptions) Run(ctx context.Context) error {
+	client, err := kubemq.GetKubemqClient(ctx, o.transport, o.cfg)
+	if err != nil {
+		return fmt.Errorf("create kubemq client, %s", err.Error())
+
+	}
+	defer func() {
+		client.Close()
+	}()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// stop on interrupt so the hits report is printed
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+	go func() {
+		select {
+		case <-sigCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	if o.reload > 0 {
+		go o.watch(ctx)
+	}
+
+	wg := sync.WaitGroup{}
+	utils.Printlnf("mocking 'queries' channel %s with %d rules from %s...", o.channel, len(o.current().Rules), o.fixturesFile)
+	err = kubemq.Subscribe(ctx, "'queries' subscription", o.reconnect, func(subCtx context.Context) error {
+		errChan := make(chan error, 1)
+		queriesChan, err := client.SubscribeToQueries(subCtx, o.channel, o.group, errChan)
+		if err != nil {
+			return fmt.Errorf("receive 'queries' messages, %s", err.Error())
+		}
+		for {
+			select {
+			case err := <-errChan:
+				return fmt.Errorf("server disconnected with error: %s", err.Error())
+			case query, opened := <-queriesChan:
+				if !opened {
+					return fmt.Errorf("server disconnected")
+				}
+				// responses are sent concurrently so a rule delay does not hold the next queries
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					o.respond(ctx, client, query)
+				}()
+			case <-subCtx.Done():
+				return nil
+			}
+		}
+	})
+	wg.Wait()
+	o.printReport()
+	return err
+}
+
+func (o *QueriesMockOptions) respond(ctx context.Context, client *kubemq2.Client, query *kubemq2.QueryReceive) {
+	f := o.current()
+	rule := f.find(query)
+	res := f.Default
+	name := "(default)"
+	if rule != nil {
+		res = rule.Response
+		name = rule.Name
+	}
+	o.hits.hit(rule, res != nil)
+	resp := client.R().SetRequestId(query.Id).SetResponseTo(query.ResponseTo)
+	if res == nil {
+		utils.Printlnf("query %s: no fixture rule matched", query.Id)
+		if err := resp.SetError(fmt.Errorf("no fixture rule matched")).Send(ctx); err != nil {
+			utils.Printlnf("query %s: send response error, %s", query.Id, err.Error())
+		}
+		return
+	}
+	if res.delay > 0 {
+		select {
+		case <-time.After(res.delay):
+		case <-ctx.Done():
+			return
+		}
+	}
+	if res.Error != "" {
+		resp.SetError(fmt.Errorf("%s", res.Error))
+	} else {
+		resp.SetExecutedAt(time.Now()).
+			SetBody([]byte(res.Body)).
+			SetMetadata(res.Metadata).
+			SetTags(res.Tags)
+	}
+	if err := resp.Send(ctx); err != nil {
+		utils.Printlnf("query %s: send response error, %s", query.Id, err.Error())
+		return
+	}
+	utils.Printlnf("query %s: responded by %s", query.Id, name)
+}
+
+func (o *QueriesMockOptions) current() *fixtures {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.fixtures
+}
+
+func (o *QueriesMockOptions) load() error {
+	info, err := os.Stat(o.fixturesFile)
+	if err != nil {
+		return fmt.Errorf("load fixtures file, %s", err.Error())
+	}
+	f, err := loadFixtures(o.fixturesFile)
+	if err != nil {
+		return err
+	}
+	// rules are registered before they are used, so every matched rule has a hits counter
+	o.hits.register(f)
+	o.mu.Lock()
+	o.fixtures = f
+	o.modTime = info.ModTime()
+	o.mu.Unlock()
+	return nil
+}
+
+// watch reloads the fixtures file when its modification time changes, an invalid file keeps the current rules
+func (o *QueriesMockOptions) watch(ctx context.Context) {
+	ticker := time.NewTicker(o.reload)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			info, err := os.Stat(o.fixturesFile)
+			if err != nil {
+				continue
+			}
+			o.mu.RLock()
+			changed := !info.ModTime().Equal(o.modTime)
+			o.mu.RUnlock()
+			if !changed {
+				continue
+			}
+			if err := o.load(); err != nil {
+				utils.Printlnf("reload fixtures file error, %s, keeping current rules", err.Error())
+				// do not retry the same invalid file
+				o.mu.Lock()
+				o.modTime = info.ModTime()
+				o.mu.Unlock()
+				continue
+			}
+			utils.Printlnf("fixtures file %s reloaded, %d rules", o.fixturesFile, len(o.current().Rules))
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (o *QueriesMockOptions) printReport() {
+	report := o.hits.report()
+	if !output.IsText() {
+		if err := output.Print(report); err != nil {
+			utils.Printlnf("output hits report error, %s", err.Error())
+		}
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.TabIndent)
+	fmt.Fprintf(w, "RULE\tHITS\n")
+	for _, hit := range report {
+		fmt.Fprintf(w, "%s\t%d\n", hit.Rule, hit.Hits)
+	}
+	w.Flush()
+}
@@ -17,6 +17,9 @@ var queriesExamples = `
 	# Execute attach to 'queries' command
 	kubemqctl queries attach
 
+	# Execute mock 'queries' command
+	kubemqctl queries mock
+
 `
 var queriesLong = `Execute Kubemq 'queries' RPC based commands`
 var queriesShort = `Execute Kubemq 'queries' RPC based commands`
@@ -30,7 +33,7 @@ func NewCmdQueries(ctx context.Context, cfg *config.Config) *cobra.Command {
 		Short:     queriesShort,
 		Long:      queriesLong,
 		Example:   queriesExamples,
-		ValidArgs: []string{"send", "receive", "attach"},
+		ValidArgs: []string{"send", "receive", "attach", "mock"},
 		Run: func(cmd *cobra.Command, args []string) {
 			utils.CheckErr(cmd.Help())
 		},
@@ -38,6 +41,7 @@ func NewCmdQueries(ctx context.Context, cfg *config.Config) *cobra.Command {
 	cmd.AddCommand(NewCmdQueriesSend(ctx, cfg))
 	cmd.AddCommand(NewCmdQueriesReceive(ctx, cfg))
 	cmd.AddCommand(NewCmdQueriesAttach(ctx, cfg))
+	cmd.AddCommand(NewCmdQueriesMock(ctx, cfg))
 
 	return cmd
 }
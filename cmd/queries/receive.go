@@ -7,6 +7,8 @@ import (
 	"github.com/kubemq-io/kubemqctl/pkg/config"
 	"github.com/kubemq-io/kubemqctl/pkg/k8s"
 	"github.com/kubemq-io/kubemqctl/pkg/kubemq"
+	"github.com/kubemq-io/kubemqctl/pkg/match"
+	"github.com/kubemq-io/kubemqctl/pkg/output"
 	"github.com/kubemq-io/kubemqctl/pkg/utils"
 	"github.com/spf13/cobra"
 	"time"
@@ -18,6 +20,13 @@ type QueriesReceiveOptions struct {
 	channel      string
 	group        string
 	autoResponse bool
+	reconnect    bool
+	responseBody string
+	bodyOutput   output.BodyOptions
+	tagFilters   []string
+	tagFilter    match.TagFilter
+	filter       string
+	filterExpr   *match.Filter
 }
 
 var queriesReceiveExamples = `
@@ -26,6 +35,12 @@ var queriesReceiveExamples = `
 
 	# Receive 'queries' from a 'queries' channel with group(blocks until next body)
 	kubemqctl queries receive some-channel -g G1
+
+	# Receive 'queries' from a 'queries' channel and auto response with a body
+	kubemqctl queries receive some-channel -a --response-body "query executed"
+
+	# Receive 'queries' from a 'queries' channel and exit when the server disconnects
+	kubemqctl queries receive some-channel --reconnect=false
 `
 var queriesReceiveLong = `Receive (Subscribe) command allows to receive a body from a 'queries' channel and response with appropriate reply`
 var queriesReceiveShort = `Receive a body from a 'queries' channel`
@@ -53,11 +68,29 @@ func NewCmdQueriesReceive(ctx context.Context, cfg *config.Config) *cobra.Comman
 
 	cmd.PersistentFlags().StringVarP(&o.group, "group", "g", "", "set 'queries' channel consumer group (load balancing)")
 	cmd.PersistentFlags().BoolVarP(&o.autoResponse, "auto-response", "a", false, "set auto response executed query")
+	cmd.PersistentFlags().BoolVarP(&o.reconnect, "reconnect", "", true, "set auto reconnect with exponential backoff when the subscription disconnects")
+	cmd.PersistentFlags().StringVarP(&o.responseBody, "response-body", "", "executed your query", "set auto response body")
+	cmd.PersistentFlags().StringArrayVarP(&o.tagFilters, "tag-filter", "", []string{}, "set handle only queries with tags matching regexes in key=regex format, repeat to filter by several tags, other queries are left without a response")
+	cmd.PersistentFlags().StringVarP(&o.filter, "filter", "", "", "set filter expression over body json paths, metadata, tags, channel, client_id and id, i.e. body.order.total > 100 && tags.region == \"eu\", queries which do not match are left without a response")
+	o.bodyOutput.AddFlags(cmd)
 	return cmd
 }
 
 func (o *QueriesReceiveOptions) Complete(args []string, transport string) error {
 	o.transport = transport
+	if err := o.bodyOutput.Complete(); err != nil {
+		return err
+	}
+	tagFilter, err := match.ParseTagFilter(o.tagFilters)
+	if err != nil {
+		return err
+	}
+	o.tagFilter = tagFilter
+	filter, err := match.ParseFilter(o.filter)
+	if err != nil {
+		return err
+	}
+	o.filterExpr = filter
 	if len(args) >= 1 {
 		o.channel = args[0]
 		return nil
@@ -66,6 +99,9 @@ func (o *QueriesReceiveOptions) Complete(args []string, transport string) error
 }
 
 func (o *QueriesReceiveOptions) Validate() error {
+	if o.bodyOutput.Raw && !o.autoResponse {
+		return fmt.Errorf("--raw requires --auto-response, queries cannot be answered by a prompt")
+	}
 	return nil
 }
 
@@ -78,71 +114,73 @@ func (o *QueriesReceiveOptions) Run(ctx context.Context) error {
 	defer func() {
 		client.Close()
 	}()
-	errChan := make(chan error, 1)
-	queriesChan, err := client.SubscribeToQueries(ctx, o.channel, o.group, errChan)
-
-	if err != nil {
-		utils.Println(fmt.Errorf("receive 'queries' messages, %s", err.Error()).Error())
-	}
-	for {
-		utils.Println("waiting for the next query body...")
-		select {
-		case err := <-errChan:
-			return fmt.Errorf("server disconnected with error: %s", err.Error())
-
-		case query, opened := <-queriesChan:
-			if !opened {
-				utils.Println("server disconnected")
-				return nil
-			}
-			printQueryReceive(query)
-			//fmt.Fprintf(w, "[channel: %s]\t[id: %s]\t[metadata: %s]\t[body: %s]\n", query.Channel, query.Id, query.Metadata, query.Body)
-			//w.Flush()
-			if o.autoResponse {
-				err = client.R().SetRequestId(query.Id).SetExecutedAt(time.Now()).SetResponseTo(query.ResponseTo).SetBody([]byte("executed your query")).Send(ctx)
-				if err != nil {
-					return err
+	return kubemq.Subscribe(ctx, "'queries' subscription", o.reconnect, func(ctx context.Context) error {
+		errChan := make(chan error, 1)
+		queriesChan, err := client.SubscribeToQueries(ctx, o.channel, o.group, errChan)
+		if err != nil {
+			return fmt.Errorf("receive 'queries' messages, %s", err.Error())
+		}
+		for {
+			utils.Println("waiting for the next query body...")
+			select {
+			case err := <-errChan:
+				return fmt.Errorf("server disconnected with error: %s", err.Error())
+
+			case query, opened := <-queriesChan:
+				if !opened {
+					return fmt.Errorf("server disconnected")
 				}
-				utils.Println("auto execution sent executed response ")
-				continue
-			}
-			var isExecuted bool
-			prompt := &survey.Confirm{
-				Renderer: survey.Renderer{},
-				Message:  "Set executed ?",
-				Help:     "",
-			}
-			err := survey.AskOne(prompt, &isExecuted)
-
-			if err != nil {
-				return err
-			}
-			if isExecuted {
-
-				respBody := ""
-				prompt := &survey.Input{
+				if !o.tagFilter.Match(query.Tags) || !o.filterExpr.Match(newFilterMessage(query)) {
+					continue
+				}
+				printQueryReceive(query)
+				//fmt.Fprintf(w, "[channel: %s]\t[id: %s]\t[metadata: %s]\t[body: %s]\n", query.Channel, query.Id, query.Metadata, query.Body)
+				//w.Flush()
+				if o.autoResponse {
+					err = client.R().SetRequestId(query.Id).SetExecutedAt(time.Now()).SetResponseTo(query.ResponseTo).SetBody([]byte(o.responseBody)).Send(ctx)
+					if err != nil {
+						return err
+					}
+					utils.Println("auto execution sent executed response ")
+					continue
+				}
+				var isExecuted bool
+				prompt := &survey.Confirm{
 					Renderer: survey.Renderer{},
-					Message:  "Set response body",
-					Default:  "response-to",
+					Message:  "Set executed ?",
 					Help:     "",
 				}
-				err := survey.AskOne(prompt, &respBody)
+				err := utils.AskOne(prompt, &isExecuted, "--auto-response flag")
+
 				if err != nil {
-					return err
+					return utils.StopReconnect(err)
 				}
-				err = client.R().SetRequestId(query.Id).SetExecutedAt(time.Now()).SetResponseTo(query.ResponseTo).SetBody([]byte(respBody)).Send(ctx)
+				if isExecuted {
+
+					respBody := ""
+					prompt := &survey.Input{
+						Renderer: survey.Renderer{},
+						Message:  "Set response body",
+						Default:  "response-to",
+						Help:     "",
+					}
+					err := utils.AskOne(prompt, &respBody, "--auto-response flag")
+					if err != nil {
+						return utils.StopReconnect(err)
+					}
+					err = client.R().SetRequestId(query.Id).SetExecutedAt(time.Now()).SetResponseTo(query.ResponseTo).SetBody([]byte(respBody)).Send(ctx)
+					if err != nil {
+						return err
+					}
+					continue
+				}
+				err = client.R().SetRequestId(query.Id).SetError(fmt.Errorf("query not executed")).SetResponseTo(query.ResponseTo).Send(ctx)
 				if err != nil {
 					return err
 				}
-				continue
-			}
-			err = client.R().SetRequestId(query.Id).SetError(fmt.Errorf("query not executed")).SetResponseTo(query.ResponseTo).Send(ctx)
-			if err != nil {
-				return err
+			case <-ctx.Done():
+				return nil
 			}
-		case <-ctx.Done():
-			return nil
 		}
-	}
-
+	})
 }
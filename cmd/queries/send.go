@@ -8,22 +8,28 @@ import (
 	"github.com/kubemq-io/kubemqctl/pkg/k8s"
 	"github.com/kubemq-io/kubemqctl/pkg/kubemq"
 	"github.com/kubemq-io/kubemqctl/pkg/targets"
+	"github.com/kubemq-io/kubemqctl/pkg/template"
 	"github.com/kubemq-io/kubemqctl/pkg/utils"
 	"github.com/spf13/cobra"
 	"time"
 )
 
 type QueriesSendOptions struct {
-	cfg       *config.Config
-	transport string
-	channel   string
-	body      string
-	metadata  string
-	timeout   int
-	cacheKey  string
-	cacheTTL  time.Duration
-	fileName  bool
-	build     bool
+	cfg           *config.Config
+	transport     string
+	channel       string
+	body          string
+	metadata      string
+	timeout       int
+	cacheKey      string
+	cacheTTL      time.Duration
+	fileName      bool
+	build         bool
+	buildMetadata string
+	buildData     string
+	message       template.MessageOptions
+	tmpl          *template.MessageTemplate
+	bodyOptions   utils.BodyOptions
 }
 
 var queriesSendExamples = `
@@ -34,10 +40,19 @@ var queriesSendExamples = `
 	kubemqctl queries send some-channel some-body -m some-metadata
 	
 	# Send query to a 'queries' channel with 120 seconds timeout
-	kubemqctl queries send some-channel some-body -o 120
+	kubemqctl queries send some-channel some-body -t 120
 	
 	# Send query to a 'queries' channel with cache-key and cache duration of 1m
 	kubemqctl queries send some-channel some-body -c cache-key -d 1m
+
+	# Send query to a 'queries' channel with a templated body
+	kubemqctl queries send some-channel --body-template '{"id":"{{ .Uuid }}","at":{{ .Unix }}}'
+
+	# Send query to a 'queries' channel with body piped from stdin
+	echo '{"id":1}' | kubemqctl queries send some-channel -
+
+	# Send query to a 'queries' channel with tags
+	kubemqctl queries send some-channel some-query --tag route=billing --tag trace-id=abc123
 `
 var queriesSendLong = `Send command allow to send messages to 'queries' channel with an option to set query time-out and caching parameters`
 var queriesSendShort = `Send messages to a 'queries' channel command`
@@ -64,10 +79,14 @@ func NewCmdQueriesSend(ctx context.Context, cfg *config.Config) *cobra.Command {
 	}
 	cmd.PersistentFlags().StringVarP(&o.metadata, "metadata", "m", "", "set query body metadata field")
 	cmd.PersistentFlags().StringVarP(&o.cacheKey, "cache-key", "c", "", "set query cache key")
-	cmd.PersistentFlags().IntVarP(&o.timeout, "timeout", "o", 30, "set query timeout")
+	cmd.PersistentFlags().IntVarP(&o.timeout, "timeout", "t", 30, "set query timeout in seconds, the shorthand is -t as -o is the global output flag")
 	cmd.PersistentFlags().DurationVarP(&o.cacheTTL, "cache-duration", "d", 10*time.Minute, "set cache duration timeout")
-	cmd.PersistentFlags().BoolVarP(&o.fileName, "file", "f", false, "set load body from file")
+	cmd.PersistentFlags().BoolVarP(&o.fileName, "file", "f", false, "set load body from file, file name is set by the body argument")
 	cmd.PersistentFlags().BoolVarP(&o.build, "build", "b", false, "build kubemq targets request")
+	cmd.PersistentFlags().StringVarP(&o.buildMetadata, "build-metadata", "", "", "set kubemq targets request metadata in key1=value1,key2=value2 format")
+	cmd.PersistentFlags().StringVarP(&o.buildData, "build-data", "", "", "set kubemq targets request data file")
+	o.message.AddFlags(cmd)
+	o.bodyOptions.AddFlags(cmd)
 	return cmd
 }
 
@@ -79,8 +98,16 @@ func (o *QueriesSendOptions) Complete(args []string, transport string) error {
 	} else {
 		return fmt.Errorf("missing channel argument")
 	}
+	tmpl, err := o.message.Complete(o.metadata)
+	if err != nil {
+		return err
+	}
+	if tmpl != nil {
+		o.tmpl = tmpl
+		return nil
+	}
 	if o.build {
-		data, err := targets.BuildRequest()
+		data, err := targets.BuildRequest(o.buildMetadata, o.buildData)
 		if err != nil {
 			return err
 		}
@@ -88,17 +115,21 @@ func (o *QueriesSendOptions) Complete(args []string, transport string) error {
 		return nil
 	}
 	if o.fileName {
-		data, err := targets.BuildFile()
+		fileName := ""
+		if len(args) >= 2 {
+			fileName = args[1]
+		}
+		data, err := targets.BuildFile(fileName)
 		if err != nil {
 			return err
 		}
 		o.body = string(data)
 	} else {
-		if len(args) >= 2 {
-			o.body = args[1]
-		} else {
-			return fmt.Errorf("missing body argument")
+		body, err := o.bodyOptions.Body(args[1:])
+		if err != nil {
+			return err
 		}
+		o.body = string(body)
 	}
 	return nil
 }
@@ -115,12 +146,17 @@ func (o *QueriesSendOptions) Run(ctx context.Context) error {
 	defer func() {
 		client.Close()
 	}()
-	fmt.Println("Sending Query:")
+	utils.Println("Sending Query:")
+	m, err := o.render(0)
+	if err != nil {
+		return err
+	}
 	msg := client.Q().
 		SetChannel(o.channel).
 		SetId(uuid.New().String()).
-		SetBody([]byte(o.body)).
-		SetMetadata(o.metadata).
+		SetBody(m.Body).
+		SetMetadata(m.Metadata).
+		SetTags(m.Tags).
 		SetTimeout(time.Duration(o.timeout) * time.Second).
 		SetCacheKey(o.cacheKey).
 		SetCacheTTL(o.cacheTTL)
@@ -129,7 +165,14 @@ func (o *QueriesSendOptions) Run(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("sending query body, %s", err.Error())
 	}
-	fmt.Println("Getting Query Response:")
+	utils.Println("Getting Query Response:")
 	printQueryResponse(res)
 	return nil
 }
+
+func (o *QueriesSendOptions) render(i int) (*template.Message, error) {
+	if o.tmpl == nil {
+		return o.message.Message([]byte(o.body), o.metadata), nil
+	}
+	return o.tmpl.Render(o.channel, i)
+}
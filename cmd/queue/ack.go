@@ -6,10 +6,16 @@ import (
 	"github.com/kubemq-io/kubemqctl/pkg/config"
 	"github.com/kubemq-io/kubemqctl/pkg/k8s"
 	"github.com/kubemq-io/kubemqctl/pkg/kubemq"
+	"github.com/kubemq-io/kubemqctl/pkg/output"
 	"github.com/kubemq-io/kubemqctl/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
+type ackResult struct {
+	Channel          string `json:"channel"`
+	AffectedMessages uint64 `json:"affected_messages"`
+}
+
 type QueueAckOptions struct {
 	cfg       *config.Config
 	transport string
@@ -85,6 +91,12 @@ func (o *QueueAckOptions) Run(ctx context.Context) error {
 	if res.IsError {
 		return fmt.Errorf("ack all 'queues' message, %s", res.Error)
 	}
+	if !output.IsText() {
+		return output.Print(&ackResult{
+			Channel:          o.channel,
+			AffectedMessages: res.AffectedMessages,
+		})
+	}
 	utils.Printlnf("acked %d messages", res.AffectedMessages)
 
 	return nil
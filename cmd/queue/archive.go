@@ -0,0 +1,115 @@
+package queue
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	kubemq "github.com/kubemq-io/kubemq-go"
+)
+
+const (
+	archiveKind    = "kubemq-queue-archive"
+	archiveVersion = 1
+)
+
+type queueArchive struct {
+	Kind       string                 `json:"kind"`
+	Version    int                    `json:"version"`
+	Channel    string                 `json:"channel"`
+	ExportedAt time.Time              `json:"exported_at"`
+	Drained    bool                   `json:"drained"`
+	Total      int                    `json:"total"`
+	Messages   []*queueArchiveMessage `json:"messages"`
+}
+
+type queueArchivePolicy struct {
+	ExpirationSeconds int32  `json:"expiration_seconds,omitempty"`
+	DelaySeconds      int32  `json:"delay_seconds,omitempty"`
+	MaxReceiveCount   int32  `json:"max_receive_count,omitempty"`
+	MaxReceiveQueue   string `json:"max_receive_queue,omitempty"`
+}
+
+type queueArchiveMessage struct {
+	Id        string              `json:"id"`
+	Sequence  uint64              `json:"sequence,omitempty"`
+	Timestamp int64               `json:"timestamp,omitempty"`
+	Metadata  string              `json:"metadata,omitempty"`
+	Tags      map[string]string   `json:"tags,omitempty"`
+	Policy    *queueArchivePolicy `json:"policy,omitempty"`
+	Body      []byte              `json:"body"`
+}
+
+func newQueueArchive(channel string, drained bool) *queueArchive {
+	return &queueArchive{
+		Kind:       archiveKind,
+		Version:    archiveVersion,
+		Channel:    channel,
+		ExportedAt: time.Now().UTC(),
+		Drained:    drained,
+	}
+}
+
+func (a *queueArchive) add(msg *kubemq.QueueMessage) {
+	m := &queueArchiveMessage{
+		Id:       msg.MessageID,
+		Metadata: msg.Metadata,
+		Tags:     msg.Tags,
+		Body:     msg.Body,
+	}
+	if msg.Attributes != nil {
+		m.Sequence = msg.Attributes.Sequence
+		m.Timestamp = msg.Attributes.Timestamp
+	}
+	if msg.Policy != nil {
+		m.Policy = &queueArchivePolicy{
+			ExpirationSeconds: msg.Policy.ExpirationSeconds,
+			DelaySeconds:      msg.Policy.DelaySeconds,
+			MaxReceiveCount:   msg.Policy.MaxReceiveCount,
+			MaxReceiveQueue:   msg.Policy.MaxReceiveQueue,
+		}
+	}
+	a.Messages = append(a.Messages, m)
+	a.Total = len(a.Messages)
+}
+
+func (a *queueArchive) save(fileName string) error {
+	data, err := json.MarshalIndent(a, "", "    ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(fileName, data, 0644)
+}
+
+func loadQueueArchive(fileName string) (*queueArchive, error) {
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	a := &queueArchive{}
+	if err := json.Unmarshal(data, a); err != nil {
+		return nil, fmt.Errorf("invalid queue archive file, %s", err.Error())
+	}
+	if a.Kind != archiveKind {
+		return nil, fmt.Errorf("invalid queue archive file, unknown kind '%s'", a.Kind)
+	}
+	if a.Version > archiveVersion {
+		return nil, fmt.Errorf("queue archive version %d is not supported, consider upgrade kubemqctl", a.Version)
+	}
+	return a, nil
+}
+
+func (m *queueArchiveMessage) toQueueMessage(msg *kubemq.QueueMessage, channel string) *kubemq.QueueMessage {
+	msg.SetChannel(channel).
+		SetBody(m.Body).
+		SetMetadata(m.Metadata).
+		SetTags(m.Tags)
+	if m.Policy != nil {
+		msg.SetPolicyExpirationSeconds(int(m.Policy.ExpirationSeconds)).
+			SetPolicyDelaySeconds(int(m.Policy.DelaySeconds)).
+			SetPolicyMaxReceiveCount(int(m.Policy.MaxReceiveCount)).
+			SetPolicyMaxReceiveQueue(m.Policy.MaxReceiveQueue)
+	}
+	return msg
+}
@@ -0,0 +1,75 @@
+package queue
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	kubemq "github.com/kubemq-io/kubemq-go"
+)
+
+type batchLine struct {
+	Channel    string            `json:"channel"`
+	Body       json.RawMessage   `json:"body"`
+	Metadata   string            `json:"metadata"`
+	Tags       map[string]string `json:"tags"`
+	Expiration int               `json:"expiration"`
+	Delay      int               `json:"delay"`
+	MaxReceive int               `json:"max_receive"`
+	DeadLetter string            `json:"dead_letter"`
+}
+
+// body returns the line body as bytes, json strings are unquoted and any other json value is sent as is
+func (l *batchLine) body() []byte {
+	if len(l.Body) == 0 {
+		return nil
+	}
+	var str string
+	if err := json.Unmarshal(l.Body, &str); err == nil {
+		return []byte(str)
+	}
+	return l.Body
+}
+
+func (l *batchLine) toQueueMessage(msg *kubemq.QueueMessage) *kubemq.QueueMessage {
+	return msg.
+		SetChannel(l.Channel).
+		SetBody(l.body()).
+		SetMetadata(l.Metadata).
+		SetTags(l.Tags).
+		SetPolicyExpirationSeconds(l.Expiration).
+		SetPolicyDelaySeconds(l.Delay).
+		SetPolicyMaxReceiveCount(l.MaxReceive).
+		SetPolicyMaxReceiveQueue(l.DeadLetter)
+}
+
+func readBatchLines(r io.Reader, defaultChannel string) ([]*batchLine, error) {
+	var lines []*batchLine
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 100*1024*1024)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		line := &batchLine{}
+		if err := json.Unmarshal([]byte(text), line); err != nil {
+			return nil, fmt.Errorf("invalid message at line %d, %s", lineNumber, err.Error())
+		}
+		if line.Channel == "" {
+			line.Channel = defaultChannel
+		}
+		if line.Channel == "" {
+			return nil, fmt.Errorf("missing channel at line %d", lineNumber)
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
@@ -0,0 +1,123 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"github.com/kubemq-io/kubemqctl/pkg/config"
+	"github.com/kubemq-io/kubemqctl/pkg/k8s"
+	"github.com/kubemq-io/kubemqctl/pkg/kubemq"
+	"github.com/kubemq-io/kubemqctl/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+type QueueExportOptions struct {
+	cfg       *config.Config
+	transport string
+	channel   string
+	fileName  string
+	drain     bool
+	messages  int
+	wait      int
+}
+
+var queueExportExamples = `
+	# Export (peek) up to 1000 messages from queue channel q1 into q1.json archive file
+	kubemqctl queue export q1 -f q1.json
+
+	# Export (drain) all messages from queue channel q1 into q1.json archive file, messages are removed from the queue
+	kubemqctl queue export q1 -f q1.json --drain
+
+	# Export (peek) up to 5000 messages from queue channel q1 and wait for 10 seconds
+	kubemqctl queue export q1 -f q1.json -m 5000 -w 10
+`
+var queueExportLong = `Export command allows to save 'queues' channel messages into an archive file for backup and migration`
+var queueExportShort = `Export 'queues' channel messages to an archive file command`
+
+func NewCmdQueueExport(ctx context.Context, cfg *config.Config) *cobra.Command {
+	o := &QueueExportOptions{
+		cfg: cfg,
+	}
+	cmd := &cobra.Command{
+
+		Use:     "export",
+		Aliases: []string{"exp"},
+		Short:   queueExportShort,
+		Long:    queueExportLong,
+		Example: queueExportExamples,
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			utils.CheckErr(o.Complete(args, cfg.ConnectionType), cmd)
+			utils.CheckErr(o.Validate())
+			utils.CheckErr(k8s.SetTransport(ctx, cfg))
+			utils.CheckErr(o.Run(ctx))
+		},
+	}
+	cmd.PersistentFlags().StringVarP(&o.fileName, "file", "f", "", "set archive file name, default <channel>.json")
+	cmd.PersistentFlags().BoolVarP(&o.drain, "drain", "", false, "set drain messages from the queue instead of peeking them")
+	cmd.PersistentFlags().IntVarP(&o.messages, "messages", "m", 1000, "set how many messages to peek, or to receive in each drain request")
+	cmd.PersistentFlags().IntVarP(&o.wait, "wait", "w", 2, "set how many seconds to wait for 'queues' messages")
+	return cmd
+}
+
+func (o *QueueExportOptions) Complete(args []string, transport string) error {
+	o.transport = transport
+	if len(args) >= 1 {
+		o.channel = args[0]
+	} else {
+		return fmt.Errorf("missing channel argument")
+	}
+	if o.fileName == "" {
+		o.fileName = fmt.Sprintf("%s.json", o.channel)
+	}
+	return nil
+}
+
+func (o *QueueExportOptions) Validate() error {
+	if o.messages <= 0 {
+		return fmt.Errorf("messages must be greater than 0")
+	}
+	return nil
+}
+
+func (o *QueueExportOptions) Run(ctx context.Context) error {
+	client, err := kubemq.GetKubemqClient(ctx, o.transport, o.cfg)
+	if err != nil {
+		return fmt.Errorf("create kubemq client, %s", err.Error())
+
+	}
+	defer func() {
+		client.Close()
+	}()
+	archive := newQueueArchive(o.channel, o.drain)
+	for {
+		res, err := client.RQM().
+			SetChannel(o.channel).
+			SetWaitTimeSeconds(o.wait).
+			SetMaxNumberOfMessages(o.messages).
+			SetIsPeak(!o.drain).
+			Send(ctx)
+		if err != nil {
+			return fmt.Errorf("export 'queues' messages, %s", err.Error())
+		}
+		if res.IsError {
+			return fmt.Errorf("export 'queues' messages, %s", res.Error)
+		}
+		for _, msg := range res.Messages {
+			archive.add(msg)
+		}
+		if !o.drain || res.MessagesReceived == 0 {
+			break
+		}
+		// drained messages are already removed from the queue, save them before pulling the next batch
+		if err := archive.save(o.fileName); err != nil {
+			return fmt.Errorf("save archive file, %s", err.Error())
+		}
+		utils.Printlnf("drained %d messages from %s 'queues' channel...", archive.Total, o.channel)
+	}
+	if err := archive.save(o.fileName); err != nil {
+		return fmt.Errorf("save archive file, %s", err.Error())
+	}
+	utils.Printlnf("%d messages exported from %s 'queues' channel to %s", archive.Total, o.channel, o.fileName)
+	return nil
+}
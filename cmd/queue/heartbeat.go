@@ -0,0 +1,70 @@
+package queue
+
+import (
+	"time"
+
+	kubemq "github.com/kubemq-io/kubemq-go"
+	"github.com/kubemq-io/kubemqctl/pkg/utils"
+)
+
+// visibilityHeartbeat extends the visibility of an in flight message in the background, half way to each visibility deadline,
+// until it is stopped or the message was held for max hold time. The server caps the visibility by the queue maxVisibilitySeconds
+// setting, once an extension is refused the heartbeat stops and the message returns to the queue when its visibility expires.
+// The stream serves one request at a time, so the heartbeat must be stopped before the message is acked, rejected or resent.
+type visibilityHeartbeat struct {
+	msg        *kubemq.QueueMessage
+	visibility int
+	maxHold    time.Duration
+	name       string
+	received   time.Time
+	done       chan struct{}
+	stopped    chan struct{}
+}
+
+func newVisibilityHeartbeat(name string, msg *kubemq.QueueMessage, visibility int, maxHold time.Duration) *visibilityHeartbeat {
+	return &visibilityHeartbeat{
+		msg:        msg,
+		visibility: visibility,
+		maxHold:    maxHold,
+		name:       name,
+		received:   time.Now(),
+	}
+}
+
+func (h *visibilityHeartbeat) start() {
+	h.done = make(chan struct{})
+	h.stopped = make(chan struct{})
+	go h.run(h.done, h.stopped)
+}
+
+// stop stops the heartbeat and waits for an in flight extension to complete
+func (h *visibilityHeartbeat) stop() {
+	if h.done == nil {
+		return
+	}
+	close(h.done)
+	<-h.stopped
+	h.done = nil
+}
+
+func (h *visibilityHeartbeat) run(done, stopped chan struct{}) {
+	defer close(stopped)
+	ticker := time.NewTicker(time.Duration(h.visibility) * time.Second / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-done:
+			return
+		}
+		held := time.Since(h.received).Round(time.Second)
+		if h.maxHold > 0 && held >= h.maxHold {
+			utils.Printlnf("%s: message %s held for max hold time of %s, visibility is not extended anymore", h.name, h.msg.MessageID, h.maxHold)
+			return
+		}
+		if err := h.msg.ExtendVisibility(int32(h.visibility)); err != nil {
+			utils.Printlnf("%s: extend visibility of message %s stopped after %s, %s, the message returns to the queue when its visibility expires", h.name, h.msg.MessageID, held, err.Error())
+			return
+		}
+	}
+}
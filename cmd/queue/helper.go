@@ -1,11 +1,11 @@
 package queue
 
 import (
-	b64 "encoding/base64"
 	"encoding/json"
-	"fmt"
 
 	kubemq "github.com/kubemq-io/kubemq-go"
+	"github.com/kubemq-io/kubemqctl/pkg/match"
+	"github.com/kubemq-io/kubemqctl/pkg/output"
 	"time"
 )
 
@@ -21,23 +21,20 @@ type queueMessageObject struct {
 	MaxDeadLetterQueueRetires int32             `json:"max_dead_letter_queue_retires,omitempty"`
 	DeadLetterQueue           string            `json:"dead_letter_queue,omitempty"`
 	Tags                      map[string]string `json:"tags,omitempty"`
-	BodyJson                  json.RawMessage   `json:"body_json,omitempty"`
-	BodyString                string            `json:"body_string,omitempty"`
+	output.Body
 }
 
 func newQueueMessageObject(msg *kubemq.QueueMessage) *queueMessageObject {
 	obj := &queueMessageObject{
-		Id:         msg.MessageID,
-		Channel:    msg.Channel,
-		ClientId:   msg.ClientID,
-		Timestamp:  "",
-		Sequence:   0,
-		Metadata:   msg.Metadata,
-		DelayTo:    "",
-		ExpireAt:   "",
-		Tags:       msg.Tags,
-		BodyJson:   json.RawMessage{},
-		BodyString: "",
+		Id:        msg.MessageID,
+		Channel:   msg.Channel,
+		ClientId:  msg.ClientID,
+		Timestamp: "",
+		Sequence:  0,
+		Metadata:  msg.Metadata,
+		DelayTo:   "",
+		ExpireAt:  "",
+		Tags:      msg.Tags,
 	}
 	if msg.Policy != nil {
 		if msg.Policy.DelaySeconds > 0 {
@@ -59,17 +56,7 @@ func newQueueMessageObject(msg *kubemq.QueueMessage) *queueMessageObject {
 			obj.ExpireAt = time.Unix(0, msg.Attributes.ExpirationAt).Format("2006-01-02 15:04:05.999")
 		}
 	}
-	var js json.RawMessage
-	if err := json.Unmarshal(msg.Body, &js); err == nil {
-		obj.BodyJson = js
-	} else {
-		sDec, err := b64.StdEncoding.DecodeString(string(msg.Body))
-		if err != nil {
-			obj.BodyString = string(msg.Body)
-		} else {
-			obj.BodyString = string(sDec)
-		}
-	}
+	obj.Body = output.NewBody(msg.Body)
 
 	return obj
 }
@@ -79,13 +66,27 @@ func (o *queueMessageObject) String() string {
 	return string(data)
 }
 
-func printItems(items []*kubemq.QueueMessage) {
+// printItems prints the messages which match the tag filter and the filter expression
+func printItems(items []*kubemq.QueueMessage, tagFilter match.TagFilter, filter *match.Filter) {
 	for _, item := range items {
-		fmt.Println(newQueueMessageObject(item))
+		if tagFilter.Match(item.Tags) && filter.Match(newFilterMessage(item)) {
+			output.Message(newQueueMessageObject(item))
+		}
+	}
+}
+func newFilterMessage(msg *kubemq.QueueMessage) *match.Message {
+	return &match.Message{
+		Id:       msg.MessageID,
+		Channel:  msg.Channel,
+		ClientId: msg.ClientID,
+		Metadata: msg.Metadata,
+		Tags:     msg.Tags,
+		Body:     msg.Body,
 	}
 }
+
 func printQueueMessage(msg *kubemq.QueueMessage) {
-	fmt.Println(newQueueMessageObject(msg))
+	output.Message(newQueueMessageObject(msg))
 }
 
 type resultObj struct {
@@ -97,6 +98,11 @@ type resultObj struct {
 	Error        string `json:"error,omitempty"`
 }
 
+func (o *resultObj) String() string {
+	data, _ := json.MarshalIndent(o, "", "    ")
+	return string(data)
+}
+
 func printQueueMessageResult(res *kubemq.SendQueueMessageResult) {
 	obj := &resultObj{
 		MessageID: res.MessageID,
@@ -110,6 +116,5 @@ func printQueueMessageResult(res *kubemq.SendQueueMessageResult) {
 	if res.DelayedTo > 0 {
 		obj.DelayedTo = time.Unix(0, res.DelayedTo).Format("2006-01-02 15:04:05.999")
 	}
-	data, _ := json.MarshalIndent(obj, "", "    ")
-	fmt.Println(string(data))
+	output.Message(obj)
 }
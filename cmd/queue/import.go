@@ -0,0 +1,126 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"github.com/kubemq-io/kubemqctl/pkg/config"
+	"github.com/kubemq-io/kubemqctl/pkg/k8s"
+	"github.com/kubemq-io/kubemqctl/pkg/kubemq"
+	"github.com/kubemq-io/kubemqctl/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+type QueueImportOptions struct {
+	cfg       *config.Config
+	transport string
+	fileName  string
+	channel   string
+	batchSize int
+	archive   *queueArchive
+}
+
+var queueImportExamples = `
+	# Import messages from q1.json archive file into the exported queue channel
+	kubemqctl queue import q1.json
+
+	# Import messages from q1.json archive file into q2 queue channel
+	kubemqctl queue import q1.json --channel q2
+
+	# Import messages from q1.json archive file in batches of 500 messages
+	kubemqctl queue import q1.json --batch-size 500
+`
+var queueImportLong = `Import command allows to replay messages from an archive file created by export command into a 'queues' channel, keeping the messages policies`
+var queueImportShort = `Import 'queues' channel messages from an archive file command`
+
+func NewCmdQueueImport(ctx context.Context, cfg *config.Config) *cobra.Command {
+	o := &QueueImportOptions{
+		cfg: cfg,
+	}
+	cmd := &cobra.Command{
+
+		Use:     "import",
+		Aliases: []string{"imp"},
+		Short:   queueImportShort,
+		Long:    queueImportLong,
+		Example: queueImportExamples,
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			utils.CheckErr(o.Complete(args, cfg.ConnectionType), cmd)
+			utils.CheckErr(o.Validate())
+			utils.CheckErr(k8s.SetTransport(ctx, cfg))
+			utils.CheckErr(o.Run(ctx))
+		},
+	}
+	cmd.PersistentFlags().StringVarP(&o.channel, "channel", "c", "", "set target queue channel, default is the exported queue channel")
+	cmd.PersistentFlags().IntVarP(&o.batchSize, "batch-size", "", 100, "set how many messages to send in each batch")
+	return cmd
+}
+
+func (o *QueueImportOptions) Complete(args []string, transport string) error {
+	o.transport = transport
+	if len(args) >= 1 {
+		o.fileName = args[0]
+	} else {
+		return fmt.Errorf("missing archive file argument")
+	}
+	archive, err := loadQueueArchive(o.fileName)
+	if err != nil {
+		return err
+	}
+	o.archive = archive
+	if o.channel == "" {
+		o.channel = archive.Channel
+	}
+	return nil
+}
+
+func (o *QueueImportOptions) Validate() error {
+	if o.channel == "" {
+		return fmt.Errorf("missing target channel, set --channel flag")
+	}
+	if o.batchSize <= 0 {
+		return fmt.Errorf("batch size must be greater than 0")
+	}
+	return nil
+}
+
+func (o *QueueImportOptions) Run(ctx context.Context) error {
+	client, err := kubemq.GetKubemqClient(ctx, o.transport, o.cfg)
+	if err != nil {
+		return fmt.Errorf("create kubemq client, %s", err.Error())
+
+	}
+	defer func() {
+		client.Close()
+	}()
+	utils.Printlnf("importing %d messages from %s into %s 'queues' channel...", len(o.archive.Messages), o.fileName, o.channel)
+	sent, failed := 0, 0
+	for start := 0; start < len(o.archive.Messages); start += o.batchSize {
+		end := start + o.batchSize
+		if end > len(o.archive.Messages) {
+			end = len(o.archive.Messages)
+		}
+		batch := client.QMB()
+		for _, m := range o.archive.Messages[start:end] {
+			batch.Add(m.toQueueMessage(client.QM(), o.channel))
+		}
+		results, err := batch.Send(ctx)
+		if err != nil {
+			return fmt.Errorf("import 'queues' messages, %s", err.Error())
+		}
+		for _, res := range results {
+			if res.IsError {
+				failed++
+				utils.Printlnf("message %s failed, %s", res.MessageID, res.Error)
+				continue
+			}
+			sent++
+		}
+	}
+	utils.Printlnf("%d messages imported, %d failed", sent, failed)
+	if failed > 0 {
+		return fmt.Errorf("%d messages failed to import", failed)
+	}
+	return nil
+}
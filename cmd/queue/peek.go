@@ -6,16 +6,23 @@ import (
 	"github.com/kubemq-io/kubemqctl/pkg/config"
 	"github.com/kubemq-io/kubemqctl/pkg/k8s"
 	"github.com/kubemq-io/kubemqctl/pkg/kubemq"
+	"github.com/kubemq-io/kubemqctl/pkg/match"
+	"github.com/kubemq-io/kubemqctl/pkg/output"
 	"github.com/kubemq-io/kubemqctl/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
 type QueuePeekOptions struct {
-	cfg       *config.Config
-	transport string
-	channel   string
-	messages  int
-	wait      int
+	cfg        *config.Config
+	transport  string
+	channel    string
+	messages   int
+	wait       int
+	bodyOutput output.BodyOptions
+	tagFilters []string
+	tagFilter  match.TagFilter
+	filter     string
+	filterExpr *match.Filter
 }
 
 var queuePeekExamples = `
@@ -51,12 +58,27 @@ func NewCmdQueuePeek(ctx context.Context, cfg *config.Config) *cobra.Command {
 
 	cmd.PersistentFlags().IntVarP(&o.messages, "messages", "m", 1, "set how many messages we want to peek from queue")
 	cmd.PersistentFlags().IntVarP(&o.wait, "wait", "w", 2, "set how many seconds to wait for peeking queue messages")
-
+	cmd.PersistentFlags().StringArrayVarP(&o.tagFilters, "tag-filter", "", []string{}, "set print only messages with tags matching regexes in key=regex format, repeat to filter by several tags")
+	cmd.PersistentFlags().StringVarP(&o.filter, "filter", "", "", "set filter expression over body json paths, metadata, tags, channel, client_id and id, i.e. body.order.total > 100 && tags.region == \"eu\", messages which do not match are not printed")
+	o.bodyOutput.AddFlags(cmd)
 	return cmd
 }
 
 func (o *QueuePeekOptions) Complete(args []string, transport string) error {
 	o.transport = transport
+	if err := o.bodyOutput.Complete(); err != nil {
+		return err
+	}
+	tagFilter, err := match.ParseTagFilter(o.tagFilters)
+	if err != nil {
+		return err
+	}
+	o.tagFilter = tagFilter
+	filter, err := match.ParseFilter(o.filter)
+	if err != nil {
+		return err
+	}
+	o.filterExpr = filter
 	if len(args) >= 1 {
 		o.channel = args[0]
 		return nil
@@ -92,7 +114,7 @@ func (o *QueuePeekOptions) Run(ctx context.Context) error {
 
 	if res.MessagesReceived > 0 {
 		utils.Printlnf("peeking %d messages", res.MessagesReceived)
-		printItems(res.Messages)
+		printItems(res.Messages, o.tagFilter, o.filterExpr)
 	} else {
 		utils.Printlnf("no messages in queue to peek")
 	}
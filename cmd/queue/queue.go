@@ -28,6 +28,21 @@ var queueExamples = `
 
 	# Execute stream 'queues' command
 	kubemqctl queues stream
+
+	# Execute export 'queues' command
+	kubemqctl queues export
+
+	# Execute import 'queues' command
+	kubemqctl queues import
+
+	# Execute redrive 'queues' command
+	kubemqctl queues redrive
+
+	# Execute work 'queues' command
+	kubemqctl queues work
+
+	# Execute wait 'queues' command
+	kubemqctl queues wait
 `
 var queueLong = `Execute Kubemq 'queues' commands`
 var queueShort = `Execute Kubemq 'queues' commands`
@@ -41,7 +56,7 @@ func NewCmdQueue(ctx context.Context, cfg *config.Config) *cobra.Command {
 		Short:     queueShort,
 		Long:      queueLong,
 		Example:   queueExamples,
-		ValidArgs: []string{"send", "receive", "attach", "peek", "ack", "list", "stream"},
+		ValidArgs: []string{"send", "receive", "attach", "peek", "ack", "list", "stream", "export", "import", "redrive", "work", "wait"},
 		Run: func(cmd *cobra.Command, args []string) {
 			utils.CheckErr(cmd.Help())
 		},
@@ -53,6 +68,11 @@ func NewCmdQueue(ctx context.Context, cfg *config.Config) *cobra.Command {
 	cmd.AddCommand(NewCmdQueueList(ctx, cfg))
 	cmd.AddCommand(NewCmdQueueStream(ctx, cfg))
 	cmd.AddCommand(NewCmdQueueAttach(ctx, cfg))
+	cmd.AddCommand(NewCmdQueueExport(ctx, cfg))
+	cmd.AddCommand(NewCmdQueueImport(ctx, cfg))
+	cmd.AddCommand(NewCmdQueueRedrive(ctx, cfg))
+	cmd.AddCommand(NewCmdQueueWork(ctx, cfg))
+	cmd.AddCommand(NewCmdQueueWait(ctx, cfg))
 
 	return cmd
 }
@@ -6,17 +6,21 @@ import (
 	"github.com/kubemq-io/kubemqctl/pkg/config"
 	"github.com/kubemq-io/kubemqctl/pkg/k8s"
 	"github.com/kubemq-io/kubemqctl/pkg/kubemq"
+	"github.com/kubemq-io/kubemqctl/pkg/output"
 	"github.com/kubemq-io/kubemqctl/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
 type QueueReceiveOptions struct {
-	cfg       *config.Config
-	transport string
-	channel   string
-	messages  int
-	wait      int
-	watch     bool
+	cfg        *config.Config
+	transport  string
+	channel    string
+	messages   int
+	wait       int
+	watch      bool
+	bodyOutput output.BodyOptions
+	tagFilters []string
+	filter     string
 }
 
 var queueReceiveExamples = `
@@ -28,8 +32,18 @@ var queueReceiveExamples = `
 
 	# Watching 'queues' channel messages
 	kubemqctl queue receive q1 -w
+
+	# Watching 'queues' channel messages and piping the message bodies, one body per line, to a consumer
+	kubemqctl queue receive q1 -w --raw | consumer
+
+	# Receive 10 messages as nul delimited bodies, byte exact
+	kubemqctl queue receive q1 -m 10 --raw --delimiter '\0' | xargs -0 -n 1 echo
+
+	# Receive 10 messages as json lines with hex encoded bodies
+	kubemqctl queue receive q1 -m 10 -o json --body-encoding hex
 `
-var queueReceiveLong = `Receive command allows to receive one or many messages from a queue channel`
+var queueReceiveLong = `Receive command allows to receive one or many messages from a queue channel, received messages are removed from the queue.
+To filter messages use 'queue peek' or 'queue stream' with --filter, receive does not filter as it would discard the messages which do not match`
 var queueReceiveShort = `Receive a messages from a queue channel command`
 
 func NewCmdQueueReceive(ctx context.Context, cfg *config.Config) *cobra.Command {
@@ -56,12 +70,20 @@ func NewCmdQueueReceive(ctx context.Context, cfg *config.Config) *cobra.Command
 	cmd.PersistentFlags().IntVarP(&o.messages, "messages", "m", 1, "set how many messages we want to get from a queue")
 	cmd.PersistentFlags().IntVarP(&o.wait, "wait-timeout", "t", 2, "set how many seconds to wait for 'queues' messages")
 	cmd.PersistentFlags().BoolVarP(&o.watch, "watch", "w", false, "set watch on 'queues' channel")
-
+	// filters are rejected with a pointer to peek and stream, receive removes the messages from the queue so unmatched messages would be lost
+	cmd.PersistentFlags().StringArrayVarP(&o.tagFilters, "tag-filter", "", []string{}, "not supported, use 'queue peek' or 'queue stream'")
+	cmd.PersistentFlags().StringVarP(&o.filter, "filter", "", "", "not supported, use 'queue peek' or 'queue stream'")
+	_ = cmd.PersistentFlags().MarkHidden("tag-filter")
+	_ = cmd.PersistentFlags().MarkHidden("filter")
+	o.bodyOutput.AddFlags(cmd)
 	return cmd
 }
 
 func (o *QueueReceiveOptions) Complete(args []string, transport string) error {
 	o.transport = transport
+	if err := o.bodyOutput.Complete(); err != nil {
+		return err
+	}
 	if len(args) >= 1 {
 		o.channel = args[0]
 		return nil
@@ -70,6 +92,9 @@ func (o *QueueReceiveOptions) Complete(args []string, transport string) error {
 }
 
 func (o *QueueReceiveOptions) Validate() error {
+	if len(o.tagFilters) > 0 || o.filter != "" {
+		return fmt.Errorf("--tag-filter and --filter cannot be set with 'queue receive', received messages are removed from the queue so unmatched messages would be lost, use 'queue peek' to filter without receiving or 'queue stream' to ack only matching messages")
+	}
 	return nil
 }
 
@@ -101,7 +126,9 @@ func (o *QueueReceiveOptions) Run(ctx context.Context) error {
 		}
 
 		if res != nil && res.MessagesReceived > 0 {
-			printItems(res.Messages)
+			for _, msg := range res.Messages {
+				printQueueMessage(msg)
+			}
 		} else if !o.watch {
 			utils.Println("No new messages in queue")
 
@@ -0,0 +1,314 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	kubemq2 "github.com/kubemq-io/kubemq-go"
+	"github.com/kubemq-io/kubemqctl/pkg/config"
+	"github.com/kubemq-io/kubemqctl/pkg/k8s"
+	"github.com/kubemq-io/kubemqctl/pkg/kubemq"
+	"github.com/kubemq-io/kubemqctl/pkg/utils"
+	"github.com/spf13/cobra"
+	"regexp"
+	"strings"
+)
+
+type QueueRedriveOptions struct {
+	cfg            *config.Config
+	transport      string
+	channel        string
+	to             string
+	metadataFilter string
+	bodyFilter     string
+	tagFilters     []string
+	max            int
+	scan           int
+	wait           int
+	visibility     int
+	dryRun         bool
+	resetPolicy    bool
+	maxReceive     int
+	deadLetter     string
+	filter         *redriveFilter
+}
+
+var queueRedriveExamples = `
+	# Redrive all messages from dead-letter queue dlq back to their original queues
+	kubemqctl queue redrive dlq
+
+	# Redrive all messages from dead-letter queue dlq to q1 queue
+	kubemqctl queue redrive dlq --to q1
+
+	# List the messages that would be redriven from dead-letter queue dlq without moving them
+	kubemqctl queue redrive dlq --dry-run
+
+	# Redrive up to 10 messages with metadata matching 'order-.*' and tag 'type' matching 'retry'
+	kubemqctl queue redrive dlq --metadata-filter "order-.*" --tag-filter type=retry --max 10
+
+	# Redrive messages with body matching 'timeout' and clear their max receive / dead-letter policy
+	kubemqctl queue redrive dlq --body-filter timeout --reset-policy
+`
+var queueRedriveLong = `Redrive command allows to move messages from a dead-letter queue back to their original queue or to a target queue`
+var queueRedriveShort = `Redrive messages from a dead-letter queue command`
+
+func NewCmdQueueRedrive(ctx context.Context, cfg *config.Config) *cobra.Command {
+	o := &QueueRedriveOptions{
+		cfg: cfg,
+	}
+	cmd := &cobra.Command{
+
+		Use:     "redrive",
+		Aliases: []string{"rd"},
+		Short:   queueRedriveShort,
+		Long:    queueRedriveLong,
+		Example: queueRedriveExamples,
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			utils.CheckErr(o.Complete(args, cfg.ConnectionType), cmd)
+			utils.CheckErr(o.Validate())
+			utils.CheckErr(k8s.SetTransport(ctx, cfg))
+			utils.CheckErr(o.Run(ctx))
+		},
+	}
+	cmd.PersistentFlags().StringVarP(&o.to, "to", "", "", "set target queue, default is the message original queue")
+	cmd.PersistentFlags().StringVarP(&o.metadataFilter, "metadata-filter", "", "", "set (regex) filter for message metadata")
+	cmd.PersistentFlags().StringVarP(&o.bodyFilter, "body-filter", "", "", "set (regex) filter for message body")
+	cmd.PersistentFlags().StringArrayVarP(&o.tagFilters, "tag-filter", "", []string{}, "set (regex) filter for message tag in key=regex format")
+	cmd.PersistentFlags().IntVarP(&o.max, "max", "", 0, "set max messages to redrive, 0 redrive all matching messages")
+	cmd.PersistentFlags().IntVarP(&o.scan, "scan", "", 1000, "set how many dead-letter queue messages to scan")
+	cmd.PersistentFlags().IntVarP(&o.wait, "wait", "w", 2, "set how many seconds to wait for dead-letter queue messages")
+	cmd.PersistentFlags().IntVarP(&o.visibility, "visibility", "v", 60, "set visibility seconds of skipped messages while redriving")
+	cmd.PersistentFlags().BoolVarP(&o.dryRun, "dry-run", "", false, "set list the messages to redrive without moving them")
+	cmd.PersistentFlags().BoolVarP(&o.resetPolicy, "reset-policy", "", false, "set clear max receive / dead-letter policy of redriven messages")
+	cmd.PersistentFlags().IntVarP(&o.maxReceive, "max-receive", "r", 0, "set new max receive count of redriven messages")
+	cmd.PersistentFlags().StringVarP(&o.deadLetter, "dead-letter-queue", "q", "", "set new dead-letter queue of redriven messages")
+	return cmd
+}
+
+func (o *QueueRedriveOptions) Complete(args []string, transport string) error {
+	o.transport = transport
+	if len(args) >= 1 {
+		o.channel = args[0]
+	} else {
+		return fmt.Errorf("missing dead-letter queue argument")
+	}
+	filter, err := newRedriveFilter(o.metadataFilter, o.bodyFilter, o.tagFilters)
+	if err != nil {
+		return err
+	}
+	o.filter = filter
+	return nil
+}
+
+func (o *QueueRedriveOptions) Validate() error {
+	if o.to == o.channel {
+		return fmt.Errorf("target queue cannot be the dead-letter queue")
+	}
+	if o.scan <= 0 {
+		return fmt.Errorf("scan must be greater than 0")
+	}
+	return nil
+}
+
+func (o *QueueRedriveOptions) Run(ctx context.Context) error {
+	client, err := kubemq.GetKubemqClient(ctx, o.transport, o.cfg)
+	if err != nil {
+		return fmt.Errorf("create kubemq client, %s", err.Error())
+
+	}
+	defer func() {
+		client.Close()
+	}()
+	res, err := client.RQM().
+		SetChannel(o.channel).
+		SetWaitTimeSeconds(o.wait).
+		SetMaxNumberOfMessages(o.scan).
+		SetIsPeak(true).
+		Send(ctx)
+	if err != nil {
+		return fmt.Errorf("scan dead-letter queue, %s", err.Error())
+	}
+	if res.IsError {
+		return fmt.Errorf("scan dead-letter queue, %s", res.Error)
+	}
+	if res.MessagesReceived == 0 {
+		utils.Printlnf("no messages in %s dead-letter queue", o.channel)
+		return nil
+	}
+	if o.dryRun {
+		return o.list(res.Messages)
+	}
+	return o.redrive(ctx, client, res.Messages)
+}
+
+func (o *QueueRedriveOptions) list(messages []*kubemq2.QueueMessage) error {
+	cnt := 0
+	for _, msg := range messages {
+		if o.max > 0 && cnt >= o.max {
+			break
+		}
+		if !o.filter.matches(msg) {
+			continue
+		}
+		target := o.target(msg)
+		if target == "" {
+			utils.Printlnf("message %s has no original queue, set --to flag to redrive it", msg.MessageID)
+			continue
+		}
+		cnt++
+		utils.Printlnf("message %s will be redriven to %s:", msg.MessageID, target)
+		printQueueMessage(msg)
+	}
+	utils.Printlnf("%d of %d scanned messages will be redriven from %s dead-letter queue", cnt, len(messages), o.channel)
+	return nil
+}
+
+// skippedMessage is a scanned message which is not redriven, it stays in flight on its stream until the scan ends
+type skippedMessage struct {
+	msg    *kubemq2.QueueMessage
+	stream *kubemq2.StreamQueueMessage
+}
+
+func (o *QueueRedriveOptions) redrive(ctx context.Context, client *kubemq2.Client, messages []*kubemq2.QueueMessage) error {
+	// the scan pulls messages until all the peeked matching messages are redriven, so skipped messages are held only while needed
+	pending := 0
+	for _, msg := range messages {
+		if o.filter.matches(msg) && o.target(msg) != "" {
+			pending++
+		}
+	}
+	if o.max > 0 && pending > o.max {
+		pending = o.max
+	}
+	// streams are not canceled by ctx, so skipped messages are still rejected back to the queue when the command is interrupted
+	streamCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// skipped messages are rejected as soon as the scan ends, a rejected message returns to the head of the queue,
+	// rejecting it during the scan would receive it again instead of the next message
+	var skipped []*skippedMessage
+	defer func() {
+		for _, s := range skipped {
+			if err := s.msg.Reject(); err != nil {
+				utils.Printlnf("reject skipped message %s error, %s, the message returns to the queue when its visibility expires", s.msg.MessageID, err.Error())
+			}
+			s.stream.Close()
+		}
+	}()
+	cnt := 0
+	for i := 0; i < len(messages) && cnt < pending; i++ {
+		if ctx.Err() != nil {
+			utils.Printlnf("redrive interrupted, %d messages redriven from %s dead-letter queue", cnt, o.channel)
+			return nil
+		}
+		stream := client.NewStreamQueueMessage().SetChannel(o.channel)
+		msg, err := stream.Next(streamCtx, int32(o.visibility), int32(o.wait))
+		if err != nil {
+			stream.Close()
+			if strings.Contains(err.Error(), "no new queue message") {
+				break
+			}
+			return fmt.Errorf("receive dead-letter queue message, %s", err.Error())
+		}
+		if msg == nil {
+			stream.Close()
+			break
+		}
+		target := o.target(msg)
+		if !o.filter.matches(msg) || target == "" {
+			skipped = append(skipped, &skippedMessage{msg: msg, stream: stream})
+			continue
+		}
+		err = stream.ResendWithNewMessage(o.newMessage(client, msg, target))
+		stream.Close()
+		if err != nil {
+			return fmt.Errorf("redrive message %s, %s", msg.MessageID, err.Error())
+		}
+		cnt++
+		utils.Printlnf("message %s redriven to %s", msg.MessageID, target)
+	}
+	utils.Printlnf("%d messages redriven from %s dead-letter queue, %d skipped", cnt, o.channel, len(skipped))
+	return nil
+}
+
+func (o *QueueRedriveOptions) target(msg *kubemq2.QueueMessage) string {
+	if o.to != "" {
+		return o.to
+	}
+	if msg.Attributes != nil {
+		return msg.Attributes.ReRoutedFromQueue
+	}
+	return ""
+}
+
+func (o *QueueRedriveOptions) newMessage(client *kubemq2.Client, msg *kubemq2.QueueMessage, target string) *kubemq2.QueueMessage {
+	maxReceive, deadLetter := 0, ""
+	if msg.Policy != nil && !o.resetPolicy {
+		maxReceive, deadLetter = int(msg.Policy.MaxReceiveCount), msg.Policy.MaxReceiveQueue
+	}
+	if o.maxReceive > 0 {
+		maxReceive = o.maxReceive
+	}
+	if o.deadLetter != "" {
+		deadLetter = o.deadLetter
+	}
+	return client.QM().
+		SetId(msg.MessageID).
+		SetChannel(target).
+		SetBody(msg.Body).
+		SetMetadata(msg.Metadata).
+		SetTags(msg.Tags).
+		SetPolicyMaxReceiveCount(maxReceive).
+		SetPolicyMaxReceiveQueue(deadLetter)
+}
+
+type redriveFilter struct {
+	metadata *regexp.Regexp
+	body     *regexp.Regexp
+	tags     map[string]*regexp.Regexp
+}
+
+func newRedriveFilter(metadata, body string, tags []string) (*redriveFilter, error) {
+	f := &redriveFilter{
+		tags: map[string]*regexp.Regexp{},
+	}
+	var err error
+	if metadata != "" {
+		if f.metadata, err = regexp.Compile(metadata); err != nil {
+			return nil, fmt.Errorf("invalid metadata filter, %s", err.Error())
+		}
+	}
+	if body != "" {
+		if f.body, err = regexp.Compile(body); err != nil {
+			return nil, fmt.Errorf("invalid body filter, %s", err.Error())
+		}
+	}
+	for _, tag := range tags {
+		kv := strings.SplitN(tag, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid tag filter %s, must have key=regex format", tag)
+		}
+		rex, err := regexp.Compile(kv[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid tag filter %s, %s", tag, err.Error())
+		}
+		f.tags[kv[0]] = rex
+	}
+	return f, nil
+}
+
+func (f *redriveFilter) matches(msg *kubemq2.QueueMessage) bool {
+	if f.metadata != nil && !f.metadata.MatchString(msg.Metadata) {
+		return false
+	}
+	if f.body != nil && !f.body.Match(msg.Body) {
+		return false
+	}
+	for key, rex := range f.tags {
+		value, ok := msg.Tags[key]
+		if !ok || !rex.MatchString(value) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,232 @@
+package queue
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"text/template"
+	"time"
+
+	"github.com/Masterminds/sprig"
+	"github.com/ghodss/yaml"
+	kubemq "github.com/kubemq-io/kubemq-go"
+	"github.com/kubemq-io/kubemqctl/pkg/match"
+)
+
+// streamSend is the new message of an ack_and_send action, body and metadata are go templates of the received message, empty templates keep the received body and metadata
+type streamSend struct {
+	Channel  string            `json:"channel"`
+	Body     string            `json:"body"`
+	Metadata string            `json:"metadata"`
+	Tags     map[string]string `json:"tags"`
+	body     *template.Template
+	metadata *template.Template
+}
+
+type streamAction struct {
+	Action   string      `json:"action"`
+	Extend   int         `json:"extend"`
+	ResendTo string      `json:"resend_to"`
+	Send     *streamSend `json:"send"`
+}
+
+type streamRule struct {
+	Name  string        `json:"name"`
+	Match match.Matcher `json:"match"`
+	streamAction
+}
+
+// streamRules is a queue stream rule table, each message is handled by the first matching rule with a final action (ack, reject, resend or ack_and_send),
+// matching extend rules extend the message visibility and the message continues to the next rules, unmatched messages get the default action
+type streamRules struct {
+	Rules   []*streamRule `json:"rules"`
+	Default *streamAction `json:"default"`
+}
+
+func loadStreamRules(fileName string) (*streamRules, error) {
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	r := &streamRules{}
+	if err := yaml.Unmarshal(data, r); err != nil {
+		return nil, fmt.Errorf("invalid rules file, %s", err.Error())
+	}
+	if err := r.compile(); err != nil {
+		return nil, fmt.Errorf("invalid rules file, %s", err.Error())
+	}
+	return r, nil
+}
+
+func (r *streamRules) compile() error {
+	for i, rule := range r.Rules {
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("rule-%d", i+1)
+		}
+		if err := rule.Match.Compile(); err != nil {
+			return fmt.Errorf("rule %s, %s", rule.Name, err.Error())
+		}
+		if err := rule.streamAction.compile(); err != nil {
+			return fmt.Errorf("rule %s, %s", rule.Name, err.Error())
+		}
+	}
+	if r.Default != nil {
+		if err := r.Default.compile(); err != nil {
+			return fmt.Errorf("default action, %s", err.Error())
+		}
+		if r.Default.Action == "extend" {
+			return fmt.Errorf("default action, extend is not a final action")
+		}
+	}
+	return nil
+}
+
+func (a *streamAction) compile() error {
+	switch a.Action {
+	case "ack", "reject":
+	case "extend":
+		if a.Extend <= 0 {
+			return fmt.Errorf("extend action requires extend seconds greater than 0")
+		}
+	case "resend":
+		if a.ResendTo == "" {
+			return fmt.Errorf("resend action requires resend_to queue")
+		}
+	case "ack_and_send":
+		if a.Send == nil || a.Send.Channel == "" {
+			return fmt.Errorf("ack_and_send action requires send channel")
+		}
+		var err error
+		if a.Send.Body != "" {
+			if a.Send.body, err = parseSendTemplate("body", a.Send.Body); err != nil {
+				return err
+			}
+		}
+		if a.Send.Metadata != "" {
+			if a.Send.metadata, err = parseSendTemplate("metadata", a.Send.Metadata); err != nil {
+				return err
+			}
+		}
+	case "":
+		return fmt.Errorf("missing action")
+	default:
+		return fmt.Errorf("invalid action %s, must be ack, reject, extend, resend or ack_and_send", a.Action)
+	}
+	return nil
+}
+
+func parseSendTemplate(name, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Funcs(sprig.TxtFuncMap()).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid send %s template, %s", name, err.Error())
+	}
+	return tmpl, nil
+}
+
+// plan returns the matching extend rules followed by the rule of the final action, the final rule is nil when no rule matches
+func (r *streamRules) plan(msg *kubemq.QueueMessage) ([]*streamRule, *streamRule) {
+	var extends []*streamRule
+	for _, rule := range r.Rules {
+		if !rule.Match.Match(msg.Metadata, msg.Tags, msg.Body) {
+			continue
+		}
+		if rule.Action == "extend" {
+			extends = append(extends, rule)
+			continue
+		}
+		return extends, rule
+	}
+	return extends, nil
+}
+
+// sendTemplateData is the data of the send body and metadata templates, Json is the parsed body when the body is a json document
+type sendTemplateData struct {
+	Id       string
+	Channel  string
+	ClientId string
+	Sequence uint64
+	Metadata string
+	Tags     map[string]string
+	Body     string
+	Json     interface{}
+}
+
+// newMessage returns the transformed message of an ack_and_send action, tags of the received message are kept and the send tags are added
+func (s *streamSend) newMessage(msg *kubemq.QueueMessage, qm *kubemq.QueueMessage) (*kubemq.QueueMessage, error) {
+	data := &sendTemplateData{
+		Id:       msg.MessageID,
+		Channel:  msg.Channel,
+		ClientId: msg.ClientID,
+		Metadata: msg.Metadata,
+		Tags:     msg.Tags,
+		Body:     string(msg.Body),
+	}
+	if msg.Attributes != nil {
+		data.Sequence = msg.Attributes.Sequence
+	}
+	_ = json.Unmarshal(msg.Body, &data.Json)
+	body := msg.Body
+	if s.body != nil {
+		buf := &bytes.Buffer{}
+		if err := s.body.Execute(buf, data); err != nil {
+			return nil, fmt.Errorf("execute send body template, %s", err.Error())
+		}
+		body = buf.Bytes()
+	}
+	metadata := msg.Metadata
+	if s.metadata != nil {
+		buf := &bytes.Buffer{}
+		if err := s.metadata.Execute(buf, data); err != nil {
+			return nil, fmt.Errorf("execute send metadata template, %s", err.Error())
+		}
+		metadata = buf.String()
+	}
+	tags := map[string]string{}
+	for key, value := range msg.Tags {
+		tags[key] = value
+	}
+	for key, value := range s.Tags {
+		tags[key] = value
+	}
+	return qm.SetChannel(s.Channel).SetBody(body).SetMetadata(metadata).SetTags(tags), nil
+}
+
+// auditEntry is a single line of the audit log, one line is written for each action applied to a message
+type auditEntry struct {
+	Time     time.Time `json:"time"`
+	Id       string    `json:"id"`
+	Channel  string    `json:"channel"`
+	Sequence uint64    `json:"sequence,omitempty"`
+	Rule     string    `json:"rule"`
+	Action   string    `json:"action"`
+	Target   string    `json:"target,omitempty"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// auditLog appends json lines to the audit file, so consecutive runs keep one audit trail
+type auditLog struct {
+	file *os.File
+}
+
+func openAuditLog(fileName string) (*auditLog, error) {
+	f, err := os.OpenFile(fileName, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &auditLog{file: f}, nil
+}
+
+func (a *auditLog) write(e *auditEntry) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = a.file.Write(append(data, '\n'))
+	return err
+}
+
+func (a *auditLog) close() error {
+	return a.file.Close()
+}
@@ -3,27 +3,45 @@ package queue
 import (
 	"context"
 	"fmt"
+	kubemq2 "github.com/kubemq-io/kubemq-go"
 	"github.com/kubemq-io/kubemqctl/pkg/config"
 	"github.com/kubemq-io/kubemqctl/pkg/k8s"
 	"github.com/kubemq-io/kubemqctl/pkg/kubemq"
+	"github.com/kubemq-io/kubemqctl/pkg/pace"
 	"github.com/kubemq-io/kubemqctl/pkg/targets"
+	"github.com/kubemq-io/kubemqctl/pkg/template"
 	"github.com/kubemq-io/kubemqctl/pkg/utils"
 	"github.com/spf13/cobra"
+	"io"
+	"os"
+	"time"
 )
 
 type QueueSendOptions struct {
-	cfg        *config.Config
-	transport  string
-	expiration int
-	delay      int
-	channel    string
-	body       string
-	maxReceive int
-	metadata   string
-	deadLetter string
-	messages   int
-	fileName   bool
-	build      bool
+	cfg           *config.Config
+	transport     string
+	expiration    int
+	delay         int
+	channel       string
+	body          string
+	maxReceive    int
+	metadata      string
+	deadLetter    string
+	messages      int
+	fileName      bool
+	build         bool
+	buildMetadata string
+	buildData     string
+	fromFile      string
+	fromStdin     bool
+	batchSize     int
+	batch         bool
+	lines         []*batchLine
+	messagesSet   bool
+	pacing        pace.Options
+	message       template.MessageOptions
+	tmpl          *template.MessageTemplate
+	bodyOptions   utils.BodyOptions
 }
 
 var queueSendExamples = `
@@ -44,6 +62,36 @@ var queueSendExamples = `
 
 	# Send message to a queue channel with a message policy of max receive 5 times and dead-letter queue 'dead-letter'
 	kubemqctl queue send q1 some-message -r 5 -q dead-letter
+
+	# Send messages from a json lines file, each line is a message object i.e {"channel":"q1","body":"some-message","tags":{"key":"value"},"expiration":10}
+	kubemqctl queue send --from-file msgs.jsonl
+
+	# Send messages from stdin to q1 queue channel (unless a line sets its own channel) in batches of 500 messages
+	cat msgs.jsonl | kubemqctl queue send q1 --from-stdin --batch-size 500
+
+	# Send message to a queue channel with body loaded from data.json file
+	kubemqctl queue send q1 data.json -f
+
+	# Send kubemq targets request to a queue channel without prompts
+	kubemqctl queue send q1 -b --build-metadata method=get,key=foo --build-data data.json --no-input
+
+	# Send queue messages at 20 messages per second for 30 minutes, progress is printed every 10 seconds
+	kubemqctl queue send q1 some-message --rate 20/s --duration 30m --progress-interval 10s
+
+	# Send 10 queue messages with a templated body, rows of orders.json are assigned to the messages round robin
+	kubemqctl queue send q1 -m 10 --body-template '{"order":{{ .Row | toJson }},"seq":{{ .Seq }}}' --data-file orders.json
+
+	# Send a queue message with tags
+	kubemqctl queue send q1 some-message --tag region=eu --tag trace-id=abc123
+
+	# Send a queue message with the body piped from stdin
+	jq -c .order data.json | kubemqctl queue send q1 -
+
+	# Send a queue message with a binary body loaded from a file, byte exact
+	kubemqctl queue send q1 @payload.bin
+
+	# Send a queue message with a base64 encoded body loaded from a file
+	kubemqctl queue send q1 --body-file payload.b64 --body-base64
 `
 var queueSendLong = `Send command allows to send one or many message to a queue channel`
 var queueSendShort = `Send a message to a queue channel command`
@@ -62,6 +110,7 @@ func NewCmdQueueSend(ctx context.Context, cfg *config.Config) *cobra.Command {
 		Run: func(cmd *cobra.Command, args []string) {
 			ctx, cancel := context.WithCancel(ctx)
 			defer cancel()
+			o.messagesSet = cmd.Flags().Changed("messages")
 			utils.CheckErr(o.Complete(args, cfg.ConnectionType), cmd)
 			utils.CheckErr(o.Validate())
 			utils.CheckErr(k8s.SetTransport(ctx, cfg))
@@ -74,22 +123,44 @@ func NewCmdQueueSend(ctx context.Context, cfg *config.Config) *cobra.Command {
 	cmd.PersistentFlags().IntVarP(&o.messages, "messages", "m", 1, "set dead-letter max receive count")
 	cmd.PersistentFlags().StringVarP(&o.deadLetter, "dead-letter-queue", "q", "", "set dead-letter queue name")
 	cmd.PersistentFlags().StringVarP(&o.metadata, "metadata", "", "", "set queue message metadata field")
-	cmd.PersistentFlags().BoolVarP(&o.fileName, "file", "f", false, "set load message body from file")
+	cmd.PersistentFlags().BoolVarP(&o.fileName, "file", "f", false, "set load message body from file, file name is set by the body argument")
 	cmd.PersistentFlags().BoolVarP(&o.build, "build", "b", false, "build kubemq targets request")
+	cmd.PersistentFlags().StringVarP(&o.buildMetadata, "build-metadata", "", "", "set kubemq targets request metadata in key1=value1,key2=value2 format")
+	cmd.PersistentFlags().StringVarP(&o.buildData, "build-data", "", "", "set kubemq targets request data file")
+	cmd.PersistentFlags().StringVarP(&o.fromFile, "from-file", "", "", "set load messages from a json lines file")
+	cmd.PersistentFlags().BoolVarP(&o.fromStdin, "from-stdin", "", false, "set load messages as json lines from stdin")
+	cmd.PersistentFlags().IntVarP(&o.batchSize, "batch-size", "", 100, "set how many messages to send in each batch when loading messages from file or stdin")
+	o.pacing.AddFlags(cmd)
+	o.message.AddFlags(cmd)
+	o.bodyOptions.AddFlags(cmd)
 
 	return cmd
 }
 
 func (o *QueueSendOptions) Complete(args []string, transport string) error {
 	o.transport = transport
+	if err := o.pacing.Complete(); err != nil {
+		return err
+	}
+	if o.fromFile != "" || o.fromStdin {
+		return o.completeBatch(args)
+	}
 	if len(args) >= 1 {
 		o.channel = args[0]
 
 	} else {
 		return fmt.Errorf("missing channel argument")
 	}
+	tmpl, err := o.message.Complete(o.metadata)
+	if err != nil {
+		return err
+	}
+	if tmpl != nil {
+		o.tmpl = tmpl
+		return nil
+	}
 	if o.build {
-		data, err := targets.BuildRequest()
+		data, err := targets.BuildRequest(o.buildMetadata, o.buildData)
 		if err != nil {
 			return err
 		}
@@ -97,27 +168,75 @@ func (o *QueueSendOptions) Complete(args []string, transport string) error {
 		return nil
 	}
 	if o.fileName {
-		data, err := targets.BuildFile()
+		fileName := ""
+		if len(args) >= 2 {
+			fileName = args[1]
+		}
+		data, err := targets.BuildFile(fileName)
 		if err != nil {
 			return err
 		}
 		o.body = string(data)
 	} else {
-		if len(args) >= 2 {
-			o.body = args[1]
-		} else {
-			return fmt.Errorf("missing body argument")
+		body, err := o.bodyOptions.Body(args[1:])
+		if err != nil {
+			return err
 		}
+		o.body = string(body)
 	}
 	return nil
 
 }
 
+func (o *QueueSendOptions) completeBatch(args []string) error {
+	if o.fromFile != "" && o.fromStdin {
+		return fmt.Errorf("only one of --from-file or --from-stdin can be set")
+	}
+	o.batch = true
+	if len(args) >= 1 {
+		o.channel = args[0]
+	}
+	var r io.Reader = os.Stdin
+	if o.fromFile != "" {
+		f, err := os.Open(o.fromFile)
+		if err != nil {
+			return fmt.Errorf("open messages file, %s", err.Error())
+		}
+		defer f.Close()
+		r = f
+	}
+	lines, err := readBatchLines(r, o.channel)
+	if err != nil {
+		return err
+	}
+	o.lines = lines
+	return nil
+}
+
 func (o *QueueSendOptions) Validate() error {
+	if o.batchSize <= 0 {
+		return fmt.Errorf("batch size must be greater than 0")
+	}
+	if err := o.pacing.Validate(); err != nil {
+		return err
+	}
+	if o.batch && o.message.IsSet() {
+		return fmt.Errorf("--from-file and --from-stdin cannot be set with --body-template")
+	}
+	if o.batch && len(o.message.Tags) > 0 {
+		return fmt.Errorf("--from-file and --from-stdin cannot be set with --tag, set the tags of each message in its line")
+	}
+	if o.batch && o.pacing.IsPaced() {
+		return fmt.Errorf("--from-file and --from-stdin cannot be set with --rate or --duration")
+	}
 	return nil
 }
 
 func (o *QueueSendOptions) Run(ctx context.Context) error {
+	if o.batch && len(o.lines) == 0 {
+		utils.Println("0 queue messages sent, the input has no messages.")
+		return nil
+	}
 	client, err := kubemq.GetKubemqClient(ctx, o.transport, o.cfg)
 	if err != nil {
 		return fmt.Errorf("create kubemq client, %s", err.Error())
@@ -126,12 +245,22 @@ func (o *QueueSendOptions) Run(ctx context.Context) error {
 	defer func() {
 		client.Close()
 	}()
-
+	if o.batch {
+		return o.runBatch(ctx, client)
+	}
+	if o.pacing.IsPaced() {
+		return o.runPaced(ctx, client)
+	}
 	for i := 0; i < o.messages; i++ {
+		m, err := o.render(i)
+		if err != nil {
+			return err
+		}
 		msg := client.QM().
 			SetChannel(o.channel).
-			SetBody([]byte(o.body)).
-			SetMetadata(o.metadata).
+			SetBody(m.Body).
+			SetMetadata(m.Metadata).
+			SetTags(m.Tags).
 			SetPolicyExpirationSeconds(o.expiration).
 			SetPolicyDelaySeconds(o.delay).
 			SetPolicyMaxReceiveCount(o.maxReceive).
@@ -150,3 +279,70 @@ func (o *QueueSendOptions) Run(ctx context.Context) error {
 
 	return nil
 }
+
+func (o *QueueSendOptions) runBatch(ctx context.Context, client *kubemq2.Client) error {
+	sent, failed := 0, 0
+	startTime := time.Now()
+	for start := 0; start < len(o.lines); start += o.batchSize {
+		end := start + o.batchSize
+		if end > len(o.lines) {
+			end = len(o.lines)
+		}
+		batch := client.QMB()
+		for _, line := range o.lines[start:end] {
+			batch.Add(line.toQueueMessage(client.QM()))
+		}
+		utils.Printlnf("Sending batch of %d queue messages:", len(batch.Messages))
+		results, err := batch.Send(ctx)
+		if err != nil {
+			return fmt.Errorf("error sending queue messages batch, %s", err.Error())
+		}
+		for _, res := range results {
+			printQueueMessageResult(res)
+			if res.IsError {
+				failed++
+			} else {
+				sent++
+			}
+		}
+	}
+	utils.Printlnf("%d queue messages sent, %d failed in %s.", sent, failed, time.Since(startTime))
+	if failed > 0 {
+		return fmt.Errorf("%d queue messages failed to send", failed)
+	}
+	return nil
+}
+
+func (o *QueueSendOptions) runPaced(ctx context.Context, client *kubemq2.Client) error {
+	utils.Printlnf("Sending queue messages to %s...", o.channel)
+	return o.pacing.Run(ctx, o.pacing.Count(o.messages, o.messagesSet), func(ctx context.Context, i int) error {
+		m, err := o.render(i)
+		if err != nil {
+			return err
+		}
+		res, err := client.QM().
+			SetChannel(o.channel).
+			SetBody(m.Body).
+			SetMetadata(m.Metadata).
+			SetTags(m.Tags).
+			SetPolicyExpirationSeconds(o.expiration).
+			SetPolicyDelaySeconds(o.delay).
+			SetPolicyMaxReceiveCount(o.maxReceive).
+			SetPolicyMaxReceiveQueue(o.deadLetter).
+			Send(ctx)
+		if err != nil {
+			return err
+		}
+		if res.IsError {
+			return fmt.Errorf("%s", res.Error)
+		}
+		return nil
+	}).Report()
+}
+
+func (o *QueueSendOptions) render(i int) (*template.Message, error) {
+	if o.tmpl == nil {
+		return o.message.Message([]byte(o.body), o.metadata), nil
+	}
+	return o.tmpl.Render(o.channel, i)
+}
@@ -4,13 +4,17 @@ import (
 	"context"
 	"fmt"
 	"github.com/AlecAivazis/survey/v2"
+	kubemq2 "github.com/kubemq-io/kubemq-go"
 	"github.com/kubemq-io/kubemqctl/pkg/config"
 	"github.com/kubemq-io/kubemqctl/pkg/k8s"
 	"github.com/kubemq-io/kubemqctl/pkg/kubemq"
+	"github.com/kubemq-io/kubemqctl/pkg/match"
+	"github.com/kubemq-io/kubemqctl/pkg/output"
 	"github.com/kubemq-io/kubemqctl/pkg/utils"
 	"github.com/spf13/cobra"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type QueueStreamOptions struct {
@@ -19,6 +23,15 @@ type QueueStreamOptions struct {
 	channel    string
 	visibility int
 	wait       int
+	action     string
+	resendTo   string
+	rulesFile  string
+	auditFile  string
+	rules      *streamRules
+	heartbeat  bool
+	maxHold    time.Duration
+	filter     string
+	filterExpr *match.Filter
 }
 
 var queueStreamExamples = `
@@ -27,8 +40,65 @@ var queueStreamExamples = `
 
 	# Stream 'queues' message in transaction mode with visibility set to 120 seconds and wait time of 180 seconds
 	kubemqctl queue stream q1 -v 120 -w 180
+
+	# Stream 'queues' messages and ack each message without prompts
+	kubemqctl queue stream q1 --action ack --no-input
+
+	# Stream 'queues' messages and resend each message to q2 without prompts
+	kubemqctl queue stream q1 --action resend --resend-to q2 --no-input
+
+	# Stream 'queues' messages and keep extending the visibility of the message while waiting for an action, for up to 10 minutes
+	kubemqctl queue stream q1 --heartbeat --max-hold 10m
+
+	# Stream 'queues' messages and handle each message by the first matching rule of rules.yaml, actions are written to q1-audit.jsonl
+	kubemqctl queue stream q1 --rules rules.yaml
+
+	# Stream 'queues' messages by rules and reject unmatched messages, actions are written to audit.jsonl
+	kubemqctl queue stream q1 --rules rules.yaml --action reject --audit-log audit.jsonl
+
+	# Stream 'queues' messages and ack only express orders, other messages are returned to the queue
+	kubemqctl queue stream q1 --filter 'body.order.type == "express"' --action ack
+
+	# Rules file example, rules match on metadata, tags (exact values), tags_regex, body regex and json body fields (dot separated paths)
+	# actions are ack, reject, extend (seconds, the message continues to the next rules), resend (to resend_to queue)
+	# and ack_and_send (send body and metadata are templates of the received message, i.e. {{ .Body }}, {{ .Json.order.id }})
+	rules:
+	  - name: slow-orders
+	    match:
+	      tags:
+	        priority: low
+	    action: extend
+	    extend: 60
+	  - name: eu-traced
+	    match:
+	      tags_regex:
+	        region: '^eu-'
+	        trace-id: '.+'
+	    action: resend
+	    resend_to: q1-eu
+	  - name: poison
+	    match:
+	      body: '^$'
+	    action: reject
+	  - name: legacy
+	    match:
+	      metadata: v1
+	    action: resend
+	    resend_to: q1-legacy
+	  - name: upgrade
+	    match:
+	      json:
+	        version: "1"
+	    action: ack_and_send
+	    send:
+	      channel: q2
+	      body: '{"version":2,"order":{{ .Json.order | toJson }}}'
+	      tags:
+	        upgraded: "true"
+	default:
+	  action: ack
 `
-var queueStreamLong = `Stream command allows to receive message from a queue in push mode response an appropriate action`
+var queueStreamLong = `Stream command allows to receive message from a queue in push mode response an appropriate action, or handle each message by a rules file without prompts`
 var queueStreamShort = `Stream a message from a queue command`
 
 func NewCmdQueueStream(ctx context.Context, cfg *config.Config) *cobra.Command {
@@ -53,20 +123,67 @@ func NewCmdQueueStream(ctx context.Context, cfg *config.Config) *cobra.Command {
 	}
 	cmd.PersistentFlags().IntVarP(&o.visibility, "visibility", "v", 30, "set initial visibility seconds")
 	cmd.PersistentFlags().IntVarP(&o.wait, "wait", "w", 60, "set how many seconds to wait for 'queues' messages")
-
+	cmd.PersistentFlags().StringVarP(&o.action, "action", "", "", "set action for each message without a prompt, ack, reject or resend")
+	cmd.PersistentFlags().StringVarP(&o.resendTo, "resend-to", "", "", "set queue channel to resend messages to with resend action")
+	cmd.PersistentFlags().BoolVarP(&o.heartbeat, "heartbeat", "", false, "set auto extend message visibility before it expires until an action is set")
+	cmd.PersistentFlags().DurationVarP(&o.maxHold, "max-hold", "", 0, "set max time to extend the visibility of a message with heartbeat, 0 extends until the queue max visibility limit")
+	cmd.PersistentFlags().StringVarP(&o.rulesFile, "rules", "", "", "set rules file (yaml or json) to handle each message by the first matching rule, --action sets the default action when the rules file has no default")
+	cmd.PersistentFlags().StringVarP(&o.auditFile, "audit-log", "", "", "set audit log file of the rules actions (json lines), default is <channel>-audit.jsonl")
+	cmd.PersistentFlags().StringVarP(&o.filter, "filter", "", "", "set filter expression over body json paths, metadata, tags, channel, client_id and id, i.e. body.order.total > 100 && tags.region == \"eu\", messages which do not match are returned to the queue right away, each return raises the message receive count so a queue with a dead-letter policy can move them to its dead-letter queue")
 	return cmd
 }
 
 func (o *QueueStreamOptions) Complete(args []string, transport string) error {
 	o.transport = transport
+	filter, err := match.ParseFilter(o.filter)
+	if err != nil {
+		return err
+	}
+	o.filterExpr = filter
 	if len(args) >= 1 {
 		o.channel = args[0]
+	} else {
+		return fmt.Errorf("missing channel argument")
+	}
+	if o.rulesFile == "" {
 		return nil
 	}
-	return fmt.Errorf("missing channel argument")
+	rules, err := loadStreamRules(o.rulesFile)
+	if err != nil {
+		return fmt.Errorf("load rules file, %s", err.Error())
+	}
+	if rules.Default == nil && o.action != "" {
+		rules.Default = &streamAction{
+			Action:   o.action,
+			ResendTo: o.resendTo,
+		}
+	}
+	o.rules = rules
+	if o.auditFile == "" {
+		o.auditFile = fmt.Sprintf("%s-audit.jsonl", o.channel)
+	}
+	return nil
 }
 
 func (o *QueueStreamOptions) Validate() error {
+	if o.heartbeat && o.visibility <= 0 {
+		return fmt.Errorf("visibility must be greater than 0 with heartbeat")
+	}
+	if o.maxHold < 0 {
+		return fmt.Errorf("max hold must be 0 or greater")
+	}
+	switch o.action {
+	case "", "ack", "reject":
+	case "resend":
+		if o.resendTo == "" {
+			return fmt.Errorf("missing --resend-to queue channel for resend action")
+		}
+	default:
+		return fmt.Errorf("invalid action %s, must be ack, reject or resend", o.action)
+	}
+	if o.rules != nil && o.rules.Default == nil {
+		return fmt.Errorf("missing default action for unmatched messages, set default in the rules file or --action flag")
+	}
 	return nil
 }
 
@@ -79,7 +196,10 @@ func (o *QueueStreamOptions) Run(ctx context.Context) error {
 	defer func() {
 		client.Close()
 	}()
-
+	if o.rules != nil {
+		return o.runRules(ctx, client)
+	}
+	skipped := map[string]bool{}
 	for {
 		stream := client.NewStreamQueueMessage().SetChannel(o.channel)
 		utils.Printlnf("waiting for the message in the queue: (waiting for %d seconds, visibility set to %d seconds)", o.wait, o.visibility)
@@ -87,9 +207,25 @@ func (o *QueueStreamOptions) Run(ctx context.Context) error {
 		if err != nil {
 			return err
 		}
-		utils.Printlnf("[channel: %s] [client id: %s] -> {id: %s, metadata: %s, body: %s}", msg.Channel, msg.ClientID, msg.MessageID, msg.Metadata, msg.Body)
+		if msg == nil {
+			return nil
+		}
+		if !o.filterExpr.Match(newFilterMessage(msg)) {
+			o.skip(ctx, stream, msg, skipped)
+			continue
+		}
+		if output.IsText() {
+			utils.Printlnf("[channel: %s] [client id: %s] -> {id: %s, metadata: %s, body: %s}", msg.Channel, msg.ClientID, msg.MessageID, msg.Metadata, msg.Body)
+		} else {
+			printQueueMessage(msg)
+		}
+		heartbeat := newVisibilityHeartbeat("stream", msg, o.visibility, o.maxHold)
 	PROMPT:
+		if o.heartbeat {
+			heartbeat.start()
+		}
 		action, result, err := o.prompt()
+		heartbeat.stop()
 		if err != nil {
 			return err
 		}
@@ -142,13 +278,133 @@ func (o *QueueStreamOptions) Run(ctx context.Context) error {
 	}
 
 }
+
+func (o *QueueStreamOptions) runRules(ctx context.Context, client *kubemq2.Client) error {
+	audit, err := openAuditLog(o.auditFile)
+	if err != nil {
+		return fmt.Errorf("open audit log, %s", err.Error())
+	}
+	defer audit.close()
+	utils.Printlnf("streaming 'queues' messages from %s with %d rules from %s, audit log %s...", o.channel, len(o.rules.Rules), o.rulesFile, o.auditFile)
+	skipped := map[string]bool{}
+	for {
+		stream := client.NewStreamQueueMessage().SetChannel(o.channel)
+		msg, err := stream.Next(ctx, int32(o.visibility), int32(o.wait))
+		if err != nil {
+			return err
+		}
+		if msg == nil {
+			return nil
+		}
+		if !o.filterExpr.Match(newFilterMessage(msg)) {
+			o.skip(ctx, stream, msg, skipped)
+			continue
+		}
+		if err := o.apply(client, stream, msg, audit); err != nil {
+			return err
+		}
+	}
+}
+
+// skip closes the stream of a message which does not match the filter, so the message returns to the queue right away.
+// A message which was already skipped means no ready message matches the filter, so the next receive waits for the wait time first
+func (o *QueueStreamOptions) skip(ctx context.Context, stream *kubemq2.StreamQueueMessage, msg *kubemq2.QueueMessage, skipped map[string]bool) {
+	stream.Close()
+	utils.Printlnf("message %s does not match the filter, returned to the queue", msg.MessageID)
+	if !skipped[msg.MessageID] {
+		skipped[msg.MessageID] = true
+		return
+	}
+	for id := range skipped {
+		delete(skipped, id)
+	}
+	utils.Printlnf("no message in the queue matches the filter, waiting %d seconds...", o.wait)
+	select {
+	case <-time.After(time.Duration(o.wait) * time.Second):
+	case <-ctx.Done():
+	}
+}
+
+// apply extends the message visibility by the matching extend rules and then applies the final action, each action is written to the audit log
+func (o *QueueStreamOptions) apply(client *kubemq2.Client, stream *kubemq2.StreamQueueMessage, msg *kubemq2.QueueMessage, audit *auditLog) error {
+	extends, rule := o.rules.plan(msg)
+	for _, extend := range extends {
+		err := msg.ExtendVisibility(int32(extend.Extend))
+		if err := o.audit(audit, msg, extend.Name, &extend.streamAction, err); err != nil {
+			return err
+		}
+	}
+	name, action := "(default)", o.rules.Default
+	if rule != nil {
+		name, action = rule.Name, &rule.streamAction
+	}
+	var err error
+	switch action.Action {
+	case "ack":
+		err = msg.Ack()
+	case "reject":
+		err = msg.Reject()
+	case "resend":
+		err = msg.Resend(action.ResendTo)
+	case "ack_and_send":
+		var newMessage *kubemq2.QueueMessage
+		newMessage, err = action.Send.newMessage(msg, client.QM())
+		if err != nil {
+			_ = msg.Reject()
+		} else {
+			err = stream.ResendWithNewMessage(newMessage)
+		}
+	}
+	return o.audit(audit, msg, name, action, err)
+}
+
+func (o *QueueStreamOptions) audit(audit *auditLog, msg *kubemq2.QueueMessage, rule string, action *streamAction, actionErr error) error {
+	entry := &auditEntry{
+		Time:    time.Now().UTC(),
+		Id:      msg.MessageID,
+		Channel: msg.Channel,
+		Rule:    rule,
+		Action:  action.Action,
+	}
+	if msg.Attributes != nil {
+		entry.Sequence = msg.Attributes.Sequence
+	}
+	switch action.Action {
+	case "extend":
+		entry.Target = fmt.Sprintf("%d seconds", action.Extend)
+	case "resend":
+		entry.Target = action.ResendTo
+	case "ack_and_send":
+		entry.Target = action.Send.Channel
+	}
+	if actionErr != nil {
+		entry.Error = actionErr.Error()
+	}
+	if err := audit.write(entry); err != nil {
+		return fmt.Errorf("write audit log, %s", err.Error())
+	}
+	if actionErr != nil {
+		return fmt.Errorf("message %s, rule %s, %s action, %s", msg.MessageID, rule, action.Action, actionErr.Error())
+	}
+	utils.Printlnf("message %s: rule %s, %s %s", msg.MessageID, rule, action.Action, entry.Target)
+	return nil
+}
+
 func (o *QueueStreamOptions) prompt() (string, string, error) {
+	switch o.action {
+	case "ack":
+		return "Ack", "", nil
+	case "reject":
+		return "Reject", "", nil
+	case "resend":
+		return "Resend to another queue", o.resendTo, nil
+	}
 	action := ""
 	prompt := &survey.Select{
 		Message: "What next:",
 		Options: []string{"Ack", "Reject", "Extend visibility", "Resend to another queue", "Ack and send new message", "Abort"},
 	}
-	err := survey.AskOne(prompt, &action)
+	err := utils.AskOne(prompt, &action, "--action flag")
 	if err != nil {
 		return "", "", err
 	}
@@ -163,7 +419,7 @@ func (o *QueueStreamOptions) prompt() (string, string, error) {
 			Default:  "60",
 			Help:     "In seconds",
 		}
-		err := survey.AskOne(prompt, &visibility)
+		err := utils.AskOne(prompt, &visibility, "--action flag")
 		if err != nil {
 			return "", "", err
 		}
@@ -176,7 +432,7 @@ func (o *QueueStreamOptions) prompt() (string, string, error) {
 			Default:  "new-queue",
 			Help:     "",
 		}
-		err := survey.AskOne(prompt, &queueName, survey.WithValidator(survey.MinLength(1)))
+		err := utils.AskOne(prompt, &queueName, "--resend-to flag", survey.WithValidator(survey.MinLength(1)))
 		if err != nil {
 			return "", "", err
 		}
@@ -189,7 +445,7 @@ func (o *QueueStreamOptions) prompt() (string, string, error) {
 			Default:  "new-queue,new-message",
 			Help:     "Format queue-name,message-body ",
 		}
-		err := survey.AskOne(prompt, &newMessage, survey.WithValidator(survey.MinLength(1)))
+		err := utils.AskOne(prompt, &newMessage, "--action flag", survey.WithValidator(survey.MinLength(1)))
 		if err != nil {
 			return "", "", err
 		}
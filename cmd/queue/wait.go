@@ -0,0 +1,190 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kubemq-io/kubemqctl/pkg/config"
+	"github.com/kubemq-io/kubemqctl/pkg/k8s"
+	"github.com/kubemq-io/kubemqctl/pkg/output"
+	"github.com/kubemq-io/kubemqctl/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// waitTimeoutExitCode is the exit code of a wait which timed out before the condition held
+const waitTimeoutExitCode = 2
+
+type QueueWaitOptions struct {
+	cfg        *config.Config
+	transport  string
+	channel    string
+	untilEmpty bool
+	min        int64
+	max        int64
+	timeout    time.Duration
+	interval   time.Duration
+	condition  string
+}
+
+type queueWaitProgress struct {
+	Time      time.Time `json:"time"`
+	Channel   string    `json:"channel"`
+	Condition string    `json:"condition"`
+	Messages  int64     `json:"messages"`
+	Pending   int64     `json:"pending"`
+	Found     bool      `json:"found"`
+	Met       bool      `json:"met"`
+}
+
+var queueWaitExamples = `
+	# Wait up to 5 minutes for queue channel q1 to be drained, no waiting and no in flight messages
+	kubemqctl queue wait q1 --until-empty --timeout 5m
+
+	# Wait for queue channel q1 to have at least 100 messages
+	kubemqctl queue wait q1 --min 100
+
+	# Wait for queue channel q1 to have at most 10 messages, polling every 5 seconds, with json progress lines
+	kubemqctl queue wait q1 --max 10 --interval 5s -o json
+`
+var queueWaitLong = `Wait command allows to poll a 'queues' channel depth until a condition holds, it exits with code 0 when the condition holds and with code 2 on timeout`
+var queueWaitShort = `Wait for a 'queues' channel depth condition command`
+
+func NewCmdQueueWait(ctx context.Context, cfg *config.Config) *cobra.Command {
+	o := &QueueWaitOptions{
+		cfg: cfg,
+	}
+	cmd := &cobra.Command{
+
+		Use:     "wait",
+		Aliases: []string{"wt"},
+		Short:   queueWaitShort,
+		Long:    queueWaitLong,
+		Example: queueWaitExamples,
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			utils.CheckErr(o.Complete(args, cfg.ConnectionType), cmd)
+			utils.CheckErr(o.Validate())
+			utils.CheckErr(k8s.SetTransport(ctx, cfg))
+			utils.CheckErr(o.Run(ctx))
+		},
+	}
+	cmd.PersistentFlags().BoolVarP(&o.untilEmpty, "until-empty", "", false, "set wait until the queue has no waiting and no in flight messages")
+	cmd.PersistentFlags().Int64VarP(&o.min, "min", "", -1, "set wait until the queue has at least this number of messages")
+	cmd.PersistentFlags().Int64VarP(&o.max, "max", "", -1, "set wait until the queue has at most this number of messages")
+	cmd.PersistentFlags().DurationVarP(&o.timeout, "timeout", "t", 5*time.Minute, "set how long to wait for the condition")
+	cmd.PersistentFlags().DurationVarP(&o.interval, "interval", "", 2*time.Second, "set queue stats poll interval")
+	return cmd
+}
+
+func (o *QueueWaitOptions) Complete(args []string, transport string) error {
+	o.transport = transport
+	if len(args) >= 1 {
+		o.channel = args[0]
+	} else {
+		return fmt.Errorf("missing channel argument")
+	}
+	switch {
+	case o.untilEmpty:
+		o.condition = "empty"
+	case o.min >= 0 && o.max >= 0:
+		o.condition = fmt.Sprintf("messages between %d and %d", o.min, o.max)
+	case o.min >= 0:
+		o.condition = fmt.Sprintf("messages at least %d", o.min)
+	case o.max >= 0:
+		o.condition = fmt.Sprintf("messages at most %d", o.max)
+	}
+	return nil
+}
+
+func (o *QueueWaitOptions) Validate() error {
+	if o.condition == "" {
+		return fmt.Errorf("missing wait condition, set --until-empty, --min or --max flag")
+	}
+	if o.untilEmpty && (o.min >= 0 || o.max >= 0) {
+		return fmt.Errorf("--until-empty cannot be set with --min or --max")
+	}
+	if o.min >= 0 && o.max >= 0 && o.min > o.max {
+		return fmt.Errorf("--min cannot be greater than --max")
+	}
+	if o.timeout <= 0 {
+		return fmt.Errorf("timeout must be greater than 0")
+	}
+	if o.interval <= 0 {
+		return fmt.Errorf("interval must be greater than 0")
+	}
+	return nil
+}
+
+func (o *QueueWaitOptions) Run(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, o.timeout)
+	defer cancel()
+	found := false
+	for {
+		// stats errors are retried until the timeout, the api can be unavailable while the cluster starts
+		q, err := fetchQueues(o.cfg)
+		if err != nil {
+			utils.Printlnf("get 'queues' stats error, %s, retrying...", err.Error())
+		} else {
+			progress := o.progress(q)
+			found = progress.Found
+			if !output.IsText() {
+				if err := output.Stream(progress); err != nil {
+					return err
+				}
+			}
+			switch {
+			case progress.Met:
+				utils.Printlnf("queue %s condition %s holds, %d messages, %d in flight", o.channel, o.condition, progress.Messages, progress.Pending)
+				return nil
+			case !progress.Found:
+				utils.Printlnf("queue %s is not listed in the 'queues' stats, waiting for it...", o.channel)
+			default:
+				utils.Printlnf("queue %s has %d messages, %d in flight, waiting for %s...", o.channel, progress.Messages, progress.Pending, o.condition)
+			}
+		}
+		select {
+		case <-time.After(o.interval):
+		case <-ctx.Done():
+			if ctx.Err() != context.DeadlineExceeded {
+				return fmt.Errorf("wait for queue %s condition %s interrupted", o.channel, o.condition)
+			}
+			msg := fmt.Sprintf("timeout after %s waiting for queue %s condition %s", o.timeout, o.channel, o.condition)
+			if !found {
+				msg += ", the queue is not listed in the 'queues' stats"
+			}
+			return &utils.ExitError{
+				Code: waitTimeoutExitCode,
+				Err:  fmt.Errorf("%s", msg),
+			}
+		}
+	}
+}
+
+// progress returns the queue depth, a queue which is not listed in the stats never meets the condition so a misspelled channel does not pass
+func (o *QueueWaitOptions) progress(q *Queues) *queueWaitProgress {
+	progress := &queueWaitProgress{
+		Time:      time.Now().UTC(),
+		Channel:   o.channel,
+		Condition: o.condition,
+	}
+	for _, item := range q.Queues {
+		if item.Name != o.channel {
+			continue
+		}
+		progress.Found = true
+		progress.Messages = item.Messages
+		for _, c := range item.Clients {
+			progress.Pending += c.Pending
+		}
+	}
+	switch {
+	case !progress.Found:
+	case o.untilEmpty:
+		progress.Met = progress.Messages == 0 && progress.Pending == 0
+	default:
+		progress.Met = (o.min < 0 || progress.Messages >= o.min) && (o.max < 0 || progress.Messages <= o.max)
+	}
+	return progress
+}
@@ -0,0 +1,112 @@
+package queue
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueueWaitOptions_Complete(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		untilEmpty bool
+		min        int64
+		max        int64
+		condition  string
+		wantErr    bool
+	}{
+		{"missing channel", nil, true, -1, -1, "", true},
+		{"until empty", []string{"q1"}, true, -1, -1, "empty", false},
+		{"min and max", []string{"q1"}, false, 10, 20, "messages between 10 and 20", false},
+		{"min", []string{"q1"}, false, 0, -1, "messages at least 0", false},
+		{"max", []string{"q1"}, false, -1, 0, "messages at most 0", false},
+		{"no condition", []string{"q1"}, false, -1, -1, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &QueueWaitOptions{untilEmpty: tt.untilEmpty, min: tt.min, max: tt.max}
+			err := o.Complete(tt.args, "grpc")
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.condition, o.condition)
+		})
+	}
+}
+
+func TestQueueWaitOptions_Validate(t *testing.T) {
+	valid := func() *QueueWaitOptions {
+		return &QueueWaitOptions{channel: "q1", min: -1, max: -1, timeout: 1, interval: 1}
+	}
+	o := valid()
+	require.Error(t, o.Validate())
+
+	o = valid()
+	o.untilEmpty, o.min = true, 1
+	require.NoError(t, o.Complete([]string{"q1"}, ""))
+	require.Error(t, o.Validate())
+
+	o = valid()
+	o.min, o.max = 2, 1
+	require.NoError(t, o.Complete([]string{"q1"}, ""))
+	require.Error(t, o.Validate())
+
+	o = valid()
+	o.untilEmpty, o.timeout = true, 0
+	require.NoError(t, o.Complete([]string{"q1"}, ""))
+	require.Error(t, o.Validate())
+
+	o = valid()
+	o.max = 0
+	require.NoError(t, o.Complete([]string{"q1"}, ""))
+	require.NoError(t, o.Validate())
+}
+
+func TestQueueWaitOptions_Progress(t *testing.T) {
+	q := &Queues{
+		Queues: []*Queue{
+			{Name: "empty"},
+			{Name: "in-flight", Clients: []*Client{{ClientId: "c1", Pending: 2}, {ClientId: "c2", Pending: 1}}},
+			{Name: "waiting", Messages: 50},
+		},
+	}
+	tests := []struct {
+		name       string
+		channel    string
+		untilEmpty bool
+		min        int64
+		max        int64
+		found      bool
+		messages   int64
+		pending    int64
+		met        bool
+	}{
+		{"empty queue is empty", "empty", true, -1, -1, true, 0, 0, true},
+		{"in flight messages are not empty", "in-flight", true, -1, -1, true, 0, 3, false},
+		{"waiting messages are not empty", "waiting", true, -1, -1, true, 50, 0, false},
+		{"missing queue is not empty", "misspelled", true, -1, -1, false, 0, 0, false},
+		{"missing queue is not at most 10", "misspelled", false, -1, 10, false, 0, 0, false},
+		{"at least", "waiting", false, 50, -1, true, 50, 0, true},
+		{"not at least", "waiting", false, 51, -1, true, 50, 0, false},
+		{"at most", "waiting", false, -1, 50, true, 50, 0, true},
+		{"not at most", "waiting", false, -1, 49, true, 50, 0, false},
+		{"between", "waiting", false, 10, 100, true, 50, 0, true},
+		{"not between", "waiting", false, 60, 100, true, 50, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &QueueWaitOptions{untilEmpty: tt.untilEmpty, min: tt.min, max: tt.max}
+			require.NoError(t, o.Complete([]string{tt.channel}, ""))
+			progress := o.progress(q)
+			require.Equal(t, tt.channel, progress.Channel)
+			require.Equal(t, o.condition, progress.Condition)
+			require.Equal(t, tt.found, progress.Found)
+			require.Equal(t, tt.messages, progress.Messages)
+			require.Equal(t, tt.pending, progress.Pending)
+			require.Equal(t, tt.met, progress.Met)
+		})
+	}
+}
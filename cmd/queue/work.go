@@ -0,0 +1,223 @@
+package queue
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	kubemq2 "github.com/kubemq-io/kubemq-go"
+	"github.com/kubemq-io/kubemqctl/pkg/config"
+	"github.com/kubemq-io/kubemqctl/pkg/k8s"
+	"github.com/kubemq-io/kubemqctl/pkg/kubemq"
+	"github.com/kubemq-io/kubemqctl/pkg/utils"
+	"github.com/spf13/cobra"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+type QueueWorkOptions struct {
+	cfg         *config.Config
+	transport   string
+	channel     string
+	command     []string
+	concurrency int
+	visibility  int
+	wait        int
+	autoExtend  bool
+	maxHold     time.Duration
+	onFailure   string
+	resendTo    string
+}
+
+var queueWorkExamples = `
+	# Run ./handler.sh for each message in queue channel q1, message body is piped to the handler stdin
+	kubemqctl queue work q1 -- ./handler.sh
+
+	# Run 5 concurrent workers with 120 seconds visibility, each message is handled by jq
+	kubemqctl queue work q1 -c 5 -v 120 -- jq .order
+
+	# Resend failed messages to q1-failed queue channel instead of rejecting them
+	kubemqctl queue work q1 --on-failure resend --resend-to q1-failed -- ./handler.sh
+
+	# Keep extending the visibility of slow messages for up to 30 minutes, then let them return to the queue
+	kubemqctl queue work q1 --max-hold 30m -- ./slow-handler.sh
+`
+var queueWorkLong = `Work command allows to consume 'queues' channel messages and run a command for each message. The message body is piped to the command stdin, and the message metadata and tags are set as KUBEMQ_* environment variables. The message is acked when the command exits with code 0, otherwise it is rejected or resent to another queue`
+var queueWorkShort = `Run a command for each message of a queue channel command`
+
+func NewCmdQueueWork(ctx context.Context, cfg *config.Config) *cobra.Command {
+	o := &QueueWorkOptions{
+		cfg: cfg,
+	}
+	cmd := &cobra.Command{
+
+		Use:     "work",
+		Aliases: []string{"w", "worker"},
+		Short:   queueWorkShort,
+		Long:    queueWorkLong,
+		Example: queueWorkExamples,
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			utils.CheckErr(o.Complete(args, cmd.ArgsLenAtDash(), cfg.ConnectionType), cmd)
+			utils.CheckErr(o.Validate())
+			utils.CheckErr(k8s.SetTransport(ctx, cfg))
+			utils.CheckErr(o.Run(ctx))
+		},
+	}
+	cmd.PersistentFlags().IntVarP(&o.concurrency, "concurrency", "c", 1, "set how many messages to handle concurrently")
+	cmd.PersistentFlags().IntVarP(&o.visibility, "visibility", "v", 30, "set message visibility seconds")
+	cmd.PersistentFlags().IntVarP(&o.wait, "wait", "w", 60, "set how many seconds to wait for 'queues' messages")
+	cmd.PersistentFlags().BoolVarP(&o.autoExtend, "auto-extend", "", true, "set auto extend message visibility while the command runs")
+	cmd.PersistentFlags().DurationVarP(&o.maxHold, "max-hold", "", 0, "set max time to extend the visibility of a message, 0 extends until the queue max visibility limit")
+	cmd.PersistentFlags().StringVarP(&o.onFailure, "on-failure", "", "reject", "set action for failed messages, reject or resend")
+	cmd.PersistentFlags().StringVarP(&o.resendTo, "resend-to", "", "", "set queue channel to resend failed messages to")
+	return cmd
+}
+
+func (o *QueueWorkOptions) Complete(args []string, dash int, transport string) error {
+	o.transport = transport
+	if dash < 0 {
+		return fmt.Errorf("missing command to run, set the command after --")
+	}
+	if dash < 1 {
+		return fmt.Errorf("missing channel argument")
+	}
+	o.channel = args[0]
+	o.command = args[dash:]
+	if len(o.command) == 0 {
+		return fmt.Errorf("missing command to run, set the command after --")
+	}
+	return nil
+}
+
+func (o *QueueWorkOptions) Validate() error {
+	if o.concurrency <= 0 {
+		return fmt.Errorf("concurrency must be greater than 0")
+	}
+	if o.visibility <= 0 {
+		return fmt.Errorf("visibility must be greater than 0")
+	}
+	if o.maxHold < 0 {
+		return fmt.Errorf("max hold must be 0 or greater")
+	}
+	switch o.onFailure {
+	case "reject":
+	case "resend":
+		if o.resendTo == "" {
+			return fmt.Errorf("missing --resend-to queue channel for resend on failure")
+		}
+	default:
+		return fmt.Errorf("invalid on-failure action %s, must be reject or resend", o.onFailure)
+	}
+	return nil
+}
+
+func (o *QueueWorkOptions) Run(ctx context.Context) error {
+	client, err := kubemq.GetKubemqClient(ctx, o.transport, o.cfg)
+	if err != nil {
+		return fmt.Errorf("create kubemq client, %s", err.Error())
+
+	}
+	defer func() {
+		client.Close()
+	}()
+	utils.Printlnf("starting %d workers on %s 'queues' channel, running: %s", o.concurrency, o.channel, strings.Join(o.command, " "))
+	wg := sync.WaitGroup{}
+	for i := 1; i <= o.concurrency; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			o.worker(ctx, client, id)
+		}(i)
+	}
+	wg.Wait()
+	return nil
+}
+
+func (o *QueueWorkOptions) worker(ctx context.Context, client *kubemq2.Client, id int) {
+	for {
+		stream := client.NewStreamQueueMessage().SetChannel(o.channel)
+		msg, err := stream.Next(ctx, int32(o.visibility), int32(o.wait))
+		if ctx.Err() != nil {
+			stream.Close()
+			return
+		}
+		if err != nil {
+			stream.Close()
+			if !strings.Contains(err.Error(), "no new queue message") {
+				utils.Printlnf("worker %d: receive message error, %s", id, err.Error())
+				time.Sleep(time.Second)
+			}
+			continue
+		}
+		if msg == nil {
+			stream.Close()
+			continue
+		}
+		o.handle(ctx, msg, id)
+		stream.Close()
+	}
+}
+
+func (o *QueueWorkOptions) handle(ctx context.Context, msg *kubemq2.QueueMessage, id int) {
+	heartbeat := newVisibilityHeartbeat(fmt.Sprintf("worker %d", id), msg, o.visibility, o.maxHold)
+	if o.autoExtend {
+		heartbeat.start()
+	}
+	start := time.Now()
+	err := o.exec(ctx, msg)
+	heartbeat.stop()
+	if err == nil {
+		if err := msg.Ack(); err != nil {
+			utils.Printlnf("worker %d: ack message %s error, %s", id, msg.MessageID, err.Error())
+			return
+		}
+		utils.Printlnf("worker %d: message %s done in %s, acked", id, msg.MessageID, time.Since(start))
+		return
+	}
+	utils.Printlnf("worker %d: message %s failed, %s", id, msg.MessageID, err.Error())
+	if o.onFailure == "resend" {
+		if err := msg.Resend(o.resendTo); err != nil {
+			utils.Printlnf("worker %d: resend message %s error, %s", id, msg.MessageID, err.Error())
+			return
+		}
+		utils.Printlnf("worker %d: message %s resent to %s", id, msg.MessageID, o.resendTo)
+		return
+	}
+	if err := msg.Reject(); err != nil {
+		utils.Printlnf("worker %d: reject message %s error, %s", id, msg.MessageID, err.Error())
+		return
+	}
+	utils.Printlnf("worker %d: message %s rejected", id, msg.MessageID)
+}
+
+func (o *QueueWorkOptions) exec(ctx context.Context, msg *kubemq2.QueueMessage) error {
+	cmd := exec.CommandContext(ctx, o.command[0], o.command[1:]...)
+	cmd.Stdin = bytes.NewReader(msg.Body)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), messageEnv(msg)...)
+	return cmd.Run()
+}
+
+func messageEnv(msg *kubemq2.QueueMessage) []string {
+	env := []string{
+		fmt.Sprintf("KUBEMQ_CHANNEL=%s", msg.Channel),
+		fmt.Sprintf("KUBEMQ_MESSAGE_ID=%s", msg.MessageID),
+		fmt.Sprintf("KUBEMQ_CLIENT_ID=%s", msg.ClientID),
+		fmt.Sprintf("KUBEMQ_METADATA=%s", msg.Metadata),
+	}
+	if msg.Attributes != nil {
+		env = append(env,
+			fmt.Sprintf("KUBEMQ_SEQUENCE=%d", msg.Attributes.Sequence),
+			fmt.Sprintf("KUBEMQ_RECEIVE_COUNT=%d", msg.Attributes.ReceiveCount),
+		)
+	}
+	for key, value := range msg.Tags {
+		env = append(env, fmt.Sprintf("KUBEMQ_TAG_%s=%s", utils.EnvKey(key), value))
+	}
+	return env
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 
+	"github.com/kubemq-io/kubemqctl/cmd/bench"
 	"github.com/kubemq-io/kubemqctl/cmd/build"
 
 	"github.com/kubemq-io/kubemqctl/cmd/generate"
@@ -24,6 +25,7 @@ import (
 	"github.com/kubemq-io/kubemqctl/cmd/events"
 
 	"github.com/kubemq-io/kubemqctl/pkg/config"
+	"github.com/kubemq-io/kubemqctl/pkg/output"
 	"github.com/kubemq-io/kubemqctl/pkg/utils"
 	"github.com/spf13/cobra"
 	"github.com/spf13/cobra/doc"
@@ -33,9 +35,15 @@ var (
 	cfg        *config.Config
 	Version    string
 	configFile string
+	outputFlag string
+	noInput    bool
 	rootCmd    = &cobra.Command{
 		Use:       "kubemqctl",
 		ValidArgs: []string{"config", "commands", "queries", "queues", "events", "events_store", "create", "get", "delete", "scale"},
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			utils.CheckErr(output.Set(outputFlag))
+			utils.SetNoInput(noInput)
+		},
 	}
 )
 
@@ -84,6 +92,7 @@ func Execute(version string, args []string) {
 	rootCmd.AddCommand(generate.NewCmdGenerate(ctx, cfg))
 	rootCmd.AddCommand(install.NewCmdInstall(ctx, cfg))
 	rootCmd.AddCommand(build.NewCmdBuild(ctx, cfg))
+	rootCmd.AddCommand(bench.NewCmdBench(ctx, cfg))
 
 	_ = doc.GenMarkdownTree(rootCmd, "./docs")
 
@@ -101,4 +110,6 @@ func exists(name string) bool {
 
 func init() {
 	rootCmd.PersistentFlags().StringVarP(&configFile, "config", "", "./.kubemqctl.yaml", "set kubemqctl configuration file")
+	rootCmd.PersistentFlags().StringVarP(&outputFlag, "output", "o", "", "set output format, one of: json|yaml|wide|jsonpath=...|go-template=..., wide adds columns to the list and get tables")
+	rootCmd.PersistentFlags().BoolVarP(&noInput, "no-input", "", os.Getenv("KUBEMQCTL_NO_INPUT") != "", "set no interactive prompts, missing values fail with an error naming the flag to set")
 }
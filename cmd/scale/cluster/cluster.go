@@ -14,13 +14,17 @@ import (
 )
 
 type ScaleOptions struct {
-	cfg   *config.Config
-	scale int
+	cfg     *config.Config
+	scale   int
+	cluster string
 }
 
 var scaleExamples = `
 	# Scale Kubemq cluster  
 	kubemqctl scale cluster 5
+
+	# Scale Kubemq cluster kubemq/kubemq-cluster without prompts
+	kubemqctl scale cluster 5 --cluster kubemq/kubemq-cluster --no-input
 `
 var scaleLong = `Scale command allows to scale Kubemq cluster replicas`
 var scaleShort = `Scale Kubemq cluster replicas command`
@@ -45,7 +49,7 @@ func NewCmdScale(ctx context.Context, cfg *config.Config) *cobra.Command {
 			utils.CheckErr(o.Run(ctx))
 		},
 	}
-
+	cmd.PersistentFlags().StringVarP(&o.cluster, "cluster", "", "", "set Kubemq cluster to scale, i.e. namespace/name")
 	return cmd
 }
 
@@ -82,21 +86,9 @@ func (o *ScaleOptions) Run(ctx context.Context) error {
 	if len(clusters.List()) == 0 {
 		return fmt.Errorf("no Kubemq cluster to scale")
 	}
-	selection := ""
-
-	if len(clusters.List()) == 1 {
-		selection = clusters.List()[0]
-	} else {
-		prompt := &survey.Select{
-			Renderer: survey.Renderer{},
-			Message:  "Select Kubemq cluster to scale:",
-			Options:  clusters.List(),
-			Default:  clusters.List()[0],
-		}
-		err = survey.AskOne(prompt, &selection)
-		if err != nil {
-			return err
-		}
+	selection, err := utils.SelectOne(o.cluster, clusters.List(), "Select Kubemq cluster to scale:", "--cluster flag")
+	if err != nil {
+		return err
 	}
 
 	if o.scale < 0 {
@@ -106,7 +98,7 @@ func (o *ScaleOptions) Run(ctx context.Context) error {
 			Default:  "",
 			Help:     "",
 		}
-		err = survey.AskOne(promptScale, &o.scale)
+		err = utils.AskOne(promptScale, &o.scale, "replicas argument")
 		if err != nil {
 			return err
 		}
@@ -3,7 +3,6 @@ package cluster
 import (
 	"context"
 	"fmt"
-	"github.com/AlecAivazis/survey/v2"
 	"github.com/kubemq-io/kubemqctl/cmd/set/cluster/proxy"
 	"github.com/kubemq-io/kubemqctl/pkg/config"
 	"github.com/kubemq-io/kubemqctl/pkg/k8s/client"
@@ -13,12 +12,15 @@ import (
 )
 
 type ClusterOptions struct {
-	cfg *config.Config
+	cfg     *config.Config
+	cluster string
 }
 
 var clusterExamples = `
 	# Execute set Kubemq cluster connection default
 	kubemqctl set cluster 
+	# Execute set Kubemq cluster connection default without prompts
+	kubemqctl set cluster --cluster kubemq/kubemq-cluster --no-input
 	# Execute set Kubemq cluster proxy
 	kubemqctl set cluster proxy	
 `
@@ -43,6 +45,7 @@ func NewCmdCluster(ctx context.Context, cfg *config.Config) *cobra.Command {
 			utils.CheckErr(o.Run(ctx))
 		},
 	}
+	cmd.Flags().StringVarP(&o.cluster, "cluster", "", "", "set default Kubemq cluster, i.e. namespace/name")
 	cmd.AddCommand(proxy.NewCmdProxy(ctx, cfg))
 	return cmd
 }
@@ -72,18 +75,7 @@ func (o *ClusterOptions) Run(ctx context.Context) error {
 	if len(clusters.List()) == 0 {
 		return fmt.Errorf("no Kubemq clusters were found")
 	}
-	selection := ""
-	multiSelected := &survey.Select{
-		Renderer:      survey.Renderer{},
-		Message:       "Select default Kubemq cluster",
-		Options:       clusters.List(),
-		Default:       clusters.List()[0],
-		PageSize:      0,
-		VimMode:       false,
-		FilterMessage: "",
-		Filter:        nil,
-	}
-	err = survey.AskOne(multiSelected, &selection)
+	selection, err := utils.SelectOne(o.cluster, clusters.List(), "Select default Kubemq cluster", "--cluster flag")
 	if err != nil {
 		return err
 	}
@@ -3,7 +3,6 @@ package proxy
 import (
 	"context"
 	"fmt"
-	"github.com/AlecAivazis/survey/v2"
 	"github.com/kubemq-io/kubemqctl/pkg/config"
 	"github.com/kubemq-io/kubemqctl/pkg/k8s"
 	"github.com/kubemq-io/kubemqctl/pkg/k8s/client"
@@ -14,13 +13,17 @@ import (
 )
 
 type ProxyOptions struct {
-	cfg *config.Config
+	cfg     *config.Config
+	cluster string
 	*k8s.ProxyOptions
 }
 
 var proxyExamples = `
 	# Proxy a Kubemq cluster ports
 	kubemqctl set cluster proxy
+
+	# Proxy Kubemq cluster kubemq/kubemq-cluster ports without prompts
+	kubemqctl set cluster proxy --cluster kubemq/kubemq-cluster --no-input
 `
 var proxyLong = `Proxy command allows to act as a full layer 4 proxy (port-forwarding) of a Kubemq cluster connection to localhost. Proxy a KubeMW cluster allows the developer to interact with remote Kubemq cluster ports as localhost `
 var proxyShort = `Proxy Kubemq cluster connection to localhost command`
@@ -44,7 +47,7 @@ func NewCmdProxy(ctx context.Context, cfg *config.Config) *cobra.Command {
 			utils.CheckErr(o.Run(ctx))
 		},
 	}
-
+	cmd.PersistentFlags().StringVarP(&o.cluster, "cluster", "", "", "set Kubemq cluster to proxy, i.e. namespace/name")
 	return cmd
 }
 
@@ -80,18 +83,7 @@ func (o *ProxyOptions) Run(ctx context.Context) error {
 	if len(clusters.List()) == 0 {
 		return fmt.Errorf("no Kubemq clusters were found to proxy")
 	}
-	selection := ""
-	multiSelected := &survey.Select{
-		Renderer:      survey.Renderer{},
-		Message:       "Select Kubemq cluster to Proxy",
-		Options:       clusters.List(),
-		Default:       clusters.List()[0],
-		PageSize:      0,
-		VimMode:       false,
-		FilterMessage: "",
-		Filter:        nil,
-	}
-	err = survey.AskOne(multiSelected, &selection)
+	selection, err := utils.SelectOne(o.cluster, clusters.List(), "Select Kubemq cluster to Proxy", "--cluster flag")
 	if err != nil {
 		return err
 	}
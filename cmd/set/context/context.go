@@ -2,6 +2,7 @@ package context
 
 import (
 	"context"
+	"fmt"
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/kubemq-io/kubemqctl/pkg/config"
 	"github.com/kubemq-io/kubemqctl/pkg/k8s/client"
@@ -12,13 +13,16 @@ import (
 )
 
 type ContextOptions struct {
-	cfg *config.Config
+	cfg         *config.Config
+	kubeContext string
 }
 
 var contextExamples = `
 	# Select kubernetes cluster context
 	kubemqctl cluster context
 
+	# Set kubernetes cluster context without prompts
+	kubemqctl set context --context my-context --no-input
 `
 var contextLong = `Context command allows to set the default Kubernetes cluster context`
 var contextShort = `Select kubernetes cluster context command`
@@ -42,7 +46,7 @@ func NewCmdContext(ctx context.Context, cfg *config.Config) *cobra.Command {
 			utils.CheckErr(o.Run(ctx))
 		},
 	}
-
+	cmd.PersistentFlags().StringVarP(&o.kubeContext, "context", "", "", "set kubernetes cluster context to switch to")
 	return cmd
 }
 
@@ -70,7 +74,13 @@ func (o *ContextOptions) Run(ctx context.Context) error {
 		list = append(list, key)
 	}
 	sort.Strings(list)
-	contextSelected := ""
+	contextSelected := o.kubeContext
+	if contextSelected != "" {
+		if _, ok := contextMap[contextSelected]; !ok {
+			return fmt.Errorf("kubernetes cluster context %s not found", contextSelected)
+		}
+		return o.switchContext(c, contextSelected)
+	}
 	contextSelect := &survey.Select{
 		Renderer:      survey.Renderer{},
 		Message:       "Select kubernetes cluster context",
@@ -82,11 +92,15 @@ func (o *ContextOptions) Run(ctx context.Context) error {
 		FilterMessage: "",
 		Filter:        nil,
 	}
-	err = survey.AskOne(contextSelect, &contextSelected)
+	err = utils.AskOne(contextSelect, &contextSelected, "--context flag")
 	if err != nil {
 		return err
 	}
-	err = c.SwitchContext(contextSelected)
+	return o.switchContext(c, contextSelected)
+}
+
+func (o *ContextOptions) switchContext(c *client.Client, contextSelected string) error {
+	err := c.SwitchContext(contextSelected)
 	if err != nil {
 		return err
 	}
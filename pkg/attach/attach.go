@@ -2,79 +2,53 @@ package attach
 
 import (
 	"context"
-	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"github.com/gorilla/websocket"
 	"github.com/kubemq-io/kubemqctl/pkg/config"
+	"github.com/kubemq-io/kubemqctl/pkg/match"
+	"github.com/kubemq-io/kubemqctl/pkg/output"
 	"github.com/kubemq-io/kubemqctl/pkg/utils"
+	"os"
 	"regexp"
 	"strings"
 	"text/tabwriter"
-
-	"os"
-	"time"
 )
 
-var retries = 10
-
-func runWebsocketClientReaderWriter(ctx context.Context, uri string, chRead chan string, chWrite chan string, ready chan struct{}, errCh chan error) {
-	var c *websocket.Conn
-	for i := 0; i < retries; i++ {
-		conn, res, err := websocket.DefaultDialer.Dial(uri, nil)
-		if err != nil {
+// runWebsocketSession dials the attach web socket, starts the attach and passes each read message to onMessage until the connection is closed or ctx is done
+func runWebsocketSession(ctx context.Context, uri string, onMessage func(msg string)) error {
+	c, res, err := websocket.DefaultDialer.Dial(uri, nil)
+	if err != nil {
+		if res != nil {
 			buf := make([]byte, 1024)
-			if res != nil {
-				n, _ := res.Body.Read(buf)
-				//	errCh <- errors.New(string(buf[:n]))
-				utils.Println(string(buf[:n]))
-			} else {
-				utils.Printlnf("error: attach web socket connection, %s", err.Error())
-
-			}
-			time.Sleep(1 * time.Second)
-		} else {
-			c = conn
-			break
+			n, _ := res.Body.Read(buf)
+			return fmt.Errorf("attach web socket connection, %s", string(buf[:n]))
 		}
+		return fmt.Errorf("attach web socket connection, %s", err.Error())
 	}
-	if c == nil {
-		os.Exit(1)
-	} else {
-		defer c.Close()
-	}
-
-	ready <- struct{}{}
+	defer c.Close()
+	done := make(chan struct{})
+	defer close(done)
 	go func() {
-		for {
-			select {
-			case msg := <-chWrite:
-				err := c.WriteMessage(1, []byte(msg))
-				if err != nil {
-					utils.Printlnf("error: attach web socket writing, %s", err.Error())
-					errCh <- err
-					return
-				}
-			case <-ctx.Done():
-				c.Close()
-				return
-			}
-
+		select {
+		case <-ctx.Done():
+			c.Close()
+		case <-done:
 		}
-
 	}()
-
+	if err := c.WriteMessage(websocket.TextMessage, []byte("start")); err != nil {
+		return fmt.Errorf("attach web socket writing, %s", err.Error())
+	}
 	for {
 		_, message, err := c.ReadMessage()
 		if err != nil {
-			utils.Printlnf("error: attach web socket reading, %s", err.Error())
-			os.Exit(0)
-			errCh <- err
-			return
-		} else {
-			chRead <- string(message)
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("attach web socket reading, %s", err.Error())
 		}
+		onMessage(string(message))
 	}
-
 }
 
 type Message struct {
@@ -82,18 +56,38 @@ type Message struct {
 	Message  string
 }
 
-func Run(ctx context.Context, cfg *config.Config, resources []string, include []string, exclude []string) error {
+type attachMessage struct {
+	Kind    string          `json:"kind"`
+	Channel string          `json:"channel"`
+	Message json.RawMessage `json:"message"`
+}
+
+func newAttachMessage(kind, channel, msg string) *attachMessage {
+	am := &attachMessage{
+		Kind:    kind,
+		Channel: channel,
+	}
+	if json.Valid([]byte(msg)) {
+		am.Message = json.RawMessage(msg)
+	} else {
+		am.Message, _ = json.Marshal(msg)
+	}
+	return am
+}
+
+// Run attaches to each resource, messages are printed when they match the include and exclude regexes and the filter expression, body paths of the filter select fields of the attached message json
+func Run(ctx context.Context, cfg *config.Config, resources []string, include []string, exclude []string, filter *match.Filter) error {
 	for _, rsc := range resources {
 		pair := strings.Split(rsc, "/")
 		if len(pair) != 2 {
 			return fmt.Errorf("invalid resource, %s", rsc)
 		}
-		go runner(ctx, cfg, pair[0], pair[1], include, exclude)
+		go runner(ctx, cfg, pair[0], pair[1], include, exclude, filter)
 	}
 	return nil
 }
 
-func runner(ctx context.Context, cfg *config.Config, resType, resChannel string, include []string, exclude []string) {
+func runner(ctx context.Context, cfg *config.Config, resType, resChannel string, include []string, exclude []string, filter *match.Filter) {
 	var exc []*regexp.Regexp
 	var inc []*regexp.Regexp
 	for _, ex := range exclude {
@@ -113,60 +107,42 @@ func runner(ctx context.Context, cfg *config.Config, resType, resChannel string,
 
 	uri := fmt.Sprintf("%s/v1/stats/attach?channel=%s&kind=%s", cfg.GetApiWsURI(), resChannel, resType)
 
-	rxChan := make(chan string, 10)
-	txChan := make(chan string, 10)
-	ready := make(chan struct{})
-	errCh := make(chan error, 10)
-	go runWebsocketClientReaderWriter(ctx, uri, rxChan, txChan, ready, errCh)
-	<-ready
-	txChan <- "start"
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.TabIndent)
-
-OUTER:
-	for {
-		select {
-		case msg := <-rxChan:
-			for _, rex := range exc {
-				if rex.MatchString(msg) {
-					continue OUTER
-				}
+	onMessage := func(msg string) {
+		for _, rex := range exc {
+			if rex.MatchString(msg) {
+				return
 			}
-
-			if len(inc) != 0 {
-				matches := false
-				for _, rin := range inc {
-					if rin.MatchString(msg) {
-						matches = true
-						break
-					}
-				}
-				if !matches {
-					continue OUTER
+		}
+		if len(inc) != 0 {
+			matches := false
+			for _, rin := range inc {
+				if rin.MatchString(msg) {
+					matches = true
+					break
 				}
 			}
-			msg = strings.Replace(msg, "\n", "", -1)
-			msg = strings.Replace(msg, "\t", " ", -1)
-			fmt.Fprintf(w, "[%s]\t[%s]\t%s\n", resType, resChannel, decodeBase64(msg))
-			w.Flush()
-		case <-ctx.Done():
+			if !matches {
+				return
+			}
+		}
+		if !filter.Match(&match.Message{Channel: resChannel, Body: []byte(msg)}) {
 			return
-		case <-errCh:
+		}
+		if output.IsRaw() {
+			output.Raw([]byte(msg))
 			return
-		default:
-			time.Sleep(1 * time.Millisecond)
 		}
-
-	}
-}
-
-func decodeBase64(in string) string {
-	// base64 string cannot contain space so this is indication of base64 string
-	if !strings.Contains(in, " ") {
-		sDec, err := base64.StdEncoding.DecodeString(in)
-		if err != nil {
-			return in
+		if !output.IsText() {
+			_ = output.Stream(newAttachMessage(resType, resChannel, msg))
+			return
 		}
-		return string(sDec)
+		msg = strings.Replace(msg, "\n", "", -1)
+		msg = strings.Replace(msg, "\t", " ", -1)
+		fmt.Fprintf(w, "[%s]\t[%s]\t%s\n", resType, resChannel, output.NewBody([]byte(msg)).Text())
+		w.Flush()
 	}
-	return in
+	_ = utils.Reconnect(ctx, fmt.Sprintf("attach %s/%s", resType, resChannel), func(ctx context.Context) error {
+		return runWebsocketSession(ctx, uri, onMessage)
+	})
 }
@@ -18,6 +18,7 @@ import (
 
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
 
 	"k8s.io/client-go/kubernetes"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
@@ -149,20 +150,66 @@ func (c *Client) GetServices(ns string, name string) (map[string]apiv1.Service,
 	return list, err
 }
 
-func (c *Client) ForwardPorts(ns string, name string, ports []string, stopChan chan struct{}, outCh chan string, errOutCh chan string) error {
+func (c *Client) podDialer(ns string, name string) (httpstream.Dialer, error) {
 	restConfig, err := c.ClientConfig.ClientConfig()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	roundTripper, upgrader, err := spdy.RoundTripperFor(restConfig)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	path := fmt.Sprintf("/api/v1/namespaces/%s/pods/%s/portforward", ns, name)
 	hostIP := strings.Replace(restConfig.Host, "https://", "", -1) //nolint
 	serverURL := url.URL{Scheme: "https", Path: path, Host: hostIP}
-	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: roundTripper}, http.MethodPost, &serverURL)
+	return spdy.NewDialer(upgrader, &http.Client{Transport: roundTripper}, http.MethodPost, &serverURL), nil
+}
+
+// ForwardPortsUntilDone forwards ports of a pod like ForwardPorts, the returned done channel is closed when the forward exits with or without an error,
+// i.e. when the pod is restarted. stopChan is owned by the caller and is never closed here
+func (c *Client) ForwardPortsUntilDone(ns string, name string, ports []string, stopChan chan struct{}, outCh chan string, errOutCh chan string) (<-chan struct{}, error) {
+	dialer, err := c.podDialer(ns, name)
+	if err != nil {
+		return nil, err
+	}
+	readyChan := make(chan struct{}, 1)
+	out, errOut := new(bytes.Buffer), new(bytes.Buffer)
+	forwarder, err := portforward.New(dialer, ports, stopChan, readyChan, out, errOut)
+	if err != nil {
+		return nil, err
+	}
+	send := func(ch chan string, str string) {
+		select {
+		case ch <- str:
+		default:
+		}
+	}
+	go func() {
+		for range readyChan {
+		}
+		if len(errOut.String()) != 0 {
+			send(errOutCh, errOut.String())
+		} else if len(out.String()) != 0 {
+			send(outCh, out.String())
+		}
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := forwarder.ForwardPorts(); err != nil {
+			send(errOutCh, err.Error())
+		}
+	}()
+	return done, nil
+}
+
+func (c *Client) ForwardPorts(ns string, name string, ports []string, stopChan chan struct{}, outCh chan string, errOutCh chan string) error {
+	dialer, err := c.podDialer(ns, name)
+	if err != nil {
+		return err
+	}
 	readyChan := make(chan struct{}, 1)
 	out, errOut := new(bytes.Buffer), new(bytes.Buffer)
 	forwarder, err := portforward.New(dialer, ports, stopChan, readyChan, out, errOut)
@@ -9,6 +9,7 @@ import (
 	"github.com/kubemq-io/kubemqctl/pkg/utils"
 	v1 "k8s.io/api/core/v1"
 	"net"
+	"sync"
 	"time"
 )
 
@@ -57,42 +58,114 @@ func SetTransport(ctx context.Context, cfg *config.Config) error {
 	cfg.RestPort = freePorts[1]
 	cfg.ApiPort = freePorts[2]
 
-	stopCh := make(chan struct{})
-	outCh, errOutCh := make(chan string, 1), make(chan string, 1)
-	err = c.ForwardPorts(podNameSpace, podName, ports, stopCh, outCh, errOutCh)
+	fw, err := startForward(c, podNameSpace, podName, ports)
 	if err != nil {
 		return err
 	}
 	select {
-	case <-outCh:
+	case <-fw.outCh:
 		utils.Printlnf("->  connected to %s/%s at gRPC Port %s Rest Port %s Api Port %s, ok", podNameSpace, podName, ports[0], ports[1], ports[2])
-	case errstr := <-errOutCh:
-		return fmt.Errorf(errstr)
-
+	case errstr := <-fw.errOutCh:
+		fw.stop()
+		return errors.New(errstr)
+	case <-fw.done:
+		return fmt.Errorf("port forward to %s/%s closed", podNameSpace, podName)
 	case <-time.After(30 * time.Second):
+		fw.stop()
 		return fmt.Errorf("timeout during setting of transport layer to kubernetes cluster")
 
 	case <-ctx.Done():
-		close(stopCh)
+		fw.stop()
 		return nil
 	}
 
 	go func() {
-		for {
+		// the forwarded pod can be restarted or rescheduled, so forward the same local ports to a running pod again
+		_ = utils.Reconnect(ctx, "kubernetes transport", func(ctx context.Context) error {
+			if fw != nil {
+				current := fw
+				fw = nil
+				return current.wait(ctx)
+			}
+			podNameSpace, podName, err := GetRunningClusterPod(c, cfg.CurrentNamespace, cfg.CurrentStatefulSet)
+			if err != nil {
+				return err
+			}
+			next, err := startForward(c, podNameSpace, podName, ports)
+			if err != nil {
+				return err
+			}
 			select {
-			case <-outCh:
-
-			case errstr := <-errOutCh:
-				utils.CheckErr(errors.New(errstr))
-				return
+			case <-next.outCh:
+				utils.Printlnf("kubernetes transport reconnected to %s/%s", podNameSpace, podName)
+			case errstr := <-next.errOutCh:
+				next.stop()
+				return errors.New(errstr)
+			case <-next.done:
+				return fmt.Errorf("port forward to %s/%s closed", podNameSpace, podName)
+			case <-time.After(30 * time.Second):
+				next.stop()
+				return fmt.Errorf("timeout during setting of transport layer to kubernetes cluster")
 			case <-ctx.Done():
-				close(stopCh)
-				return
+				next.stop()
+				return nil
 			}
-		}
+			return next.wait(ctx)
+		})
 	}()
 	return nil
 }
+
+// forward is a running port forward to a pod, stopCh is owned by the forward and closed once by stop
+type forward struct {
+	name     string
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	outCh    chan string
+	errOutCh chan string
+	done     <-chan struct{}
+}
+
+func startForward(c *client.Client, ns, name string, ports []string) (*forward, error) {
+	fw := &forward{
+		name:     fmt.Sprintf("%s/%s", ns, name),
+		stopCh:   make(chan struct{}),
+		outCh:    make(chan string, 1),
+		errOutCh: make(chan string, 1),
+	}
+	done, err := c.ForwardPortsUntilDone(ns, name, ports, fw.stopCh, fw.outCh, fw.errOutCh)
+	if err != nil {
+		return nil, err
+	}
+	fw.done = done
+	return fw, nil
+}
+
+func (fw *forward) stop() {
+	fw.stopOnce.Do(func() {
+		close(fw.stopCh)
+	})
+}
+
+// wait blocks until the port forward exits, with or without an error, or ctx is done, in which case the forward is stopped
+func (fw *forward) wait(ctx context.Context) error {
+	for {
+		select {
+		case <-fw.outCh:
+
+		case errstr := <-fw.errOutCh:
+			fw.stop()
+			return errors.New(errstr)
+		case <-fw.done:
+			fw.stop()
+			return fmt.Errorf("port forward to %s closed", fw.name)
+		case <-ctx.Done():
+			fw.stop()
+			return nil
+		}
+	}
+}
+
 func possibleMap(name string) map[string]string {
 	m := map[string]string{}
 	for i := 0; i < 16; i++ {
@@ -0,0 +1,85 @@
+package kubemq
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/kubemq-io/kubemqctl/pkg/config"
+)
+
+type statsResponse struct {
+	Error       bool            `json:"error"`
+	ErrorString string          `json:"error_string"`
+	Data        json.RawMessage `json:"data"`
+}
+
+type statsChannels struct {
+	Queues []struct {
+		Name string `json:"name"`
+	} `json:"queues"`
+}
+
+// ListChannels returns the channel names of a stats resource, i.e. events_stores or queues
+func ListChannels(cfg *config.Config, resource string) ([]string, error) {
+	resp := &statsResponse{}
+	r, err := resty.New().R().SetResult(resp).SetError(resp).Get(fmt.Sprintf("%s/v1/stats/%s", cfg.GetApiHttpURI(), resource))
+	if err != nil {
+		return nil, err
+	}
+	if !r.IsSuccess() {
+		return nil, fmt.Errorf("not available in current Kubemq version, consider upgrade Kubemq version")
+	}
+	if resp.Error {
+		return nil, fmt.Errorf(resp.ErrorString)
+	}
+	list := &statsChannels{}
+	if err := json.Unmarshal(resp.Data, list); err != nil {
+		return nil, err
+	}
+	var channels []string
+	for _, q := range list.Queues {
+		channels = append(channels, q.Name)
+	}
+	return channels, nil
+}
+
+// IsWildcard returns true when the channel is a glob pattern
+func IsWildcard(channel string) bool {
+	return strings.ContainsAny(channel, "*?[")
+}
+
+// MatchChannels expands the glob patterns against the channels list, channels without wildcards are returned as is. Each channel is returned once, in patterns order
+func MatchChannels(patterns, channels []string) ([]string, error) {
+	var result []string
+	seen := map[string]bool{}
+	add := func(channel string) {
+		if !seen[channel] {
+			seen[channel] = true
+			result = append(result, channel)
+		}
+	}
+	for _, pattern := range patterns {
+		if !IsWildcard(pattern) {
+			add(pattern)
+			continue
+		}
+		matched := false
+		for _, channel := range channels {
+			ok, err := path.Match(pattern, channel)
+			if err != nil {
+				return nil, fmt.Errorf("invalid channel pattern %s, %s", pattern, err.Error())
+			}
+			if ok {
+				matched = true
+				add(channel)
+			}
+		}
+		if !matched {
+			return nil, fmt.Errorf("no channels match %s", pattern)
+		}
+	}
+	return result, nil
+}
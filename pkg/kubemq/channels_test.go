@@ -0,0 +1,20 @@
+package kubemq
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchChannels(t *testing.T) {
+	channels := []string{"orders.created", "orders.paid", "payments.failed"}
+	result, err := MatchChannels([]string{"orders.*", "payments.failed", "orders.paid", "audit"}, channels)
+	require.NoError(t, err)
+	require.Equal(t, []string{"orders.created", "orders.paid", "payments.failed", "audit"}, result)
+
+	_, err = MatchChannels([]string{"users.*"}, channels)
+	require.Error(t, err)
+
+	_, err = MatchChannels([]string{"orders.["}, channels)
+	require.Error(t, err)
+}
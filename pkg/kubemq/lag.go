@@ -0,0 +1,155 @@
+package kubemq
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/kubemq-io/kubemqctl/pkg/utils"
+)
+
+// LagExceededExitCode is the exit code of a lag check which found clients over the max lag, or stalled clients
+const LagExceededExitCode = 2
+
+// ClientLag is the consumer lag of a client on a channel
+type ClientLag struct {
+	Channel          string `json:"channel"`
+	ClientId         string `json:"client_id"`
+	Group            string `json:"group"`
+	Active           bool   `json:"active"`
+	LastSequence     int64  `json:"last_sequence"`
+	LastSequenceSent int64  `json:"last_sequence_sent"`
+	Lag              int64  `json:"lag"`
+	GroupLag         int64  `json:"group_lag"`
+	Pending          int64  `json:"pending"`
+	Stalled          bool   `json:"stalled"`
+}
+
+// LagOptions sets the lag report filter, sort order and check limits
+type LagOptions struct {
+	MinLag      int64
+	MaxLag      int64
+	FailStalled bool
+	Sort        string
+}
+
+var lagSortFields = []string{"lag", "pending", "channel", "client"}
+
+func (o *LagOptions) Validate() error {
+	if o.MinLag < 0 {
+		return fmt.Errorf("min lag cannot be negative")
+	}
+	if o.MaxLag < 0 {
+		return fmt.Errorf("max lag cannot be negative")
+	}
+	for _, field := range lagSortFields {
+		if o.Sort == field {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid sort field %s, must be one of %v", o.Sort, lagSortFields)
+}
+
+// SetGroupLags sets the group lag of the clients, the lag of a consumer group is the min lag of its members on the channel
+// as any member progress is the group progress, a client without a group is its own group
+func SetGroupLags(clients []*ClientLag) {
+	groupLags := map[[2]string]int64{}
+	for _, c := range clients {
+		if c.Group == "" {
+			continue
+		}
+		key := [2]string{c.Channel, c.Group}
+		if lag, ok := groupLags[key]; !ok || c.Lag < lag {
+			groupLags[key] = c.Lag
+		}
+	}
+	for _, c := range clients {
+		c.GroupLag = c.Lag
+		if c.Group != "" {
+			c.GroupLag = groupLags[[2]string{c.Channel, c.Group}]
+		}
+	}
+}
+
+// Report returns the clients with at least the min lag, sorted by the sort field, lag and pending are sorted in descending order
+func (o *LagOptions) Report(clients []*ClientLag) []*ClientLag {
+	SetGroupLags(clients)
+	var list []*ClientLag
+	for _, c := range clients {
+		if c.Lag >= o.MinLag {
+			list = append(list, c)
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		switch o.Sort {
+		case "lag":
+			return list[i].Lag > list[j].Lag
+		case "pending":
+			return list[i].Pending > list[j].Pending
+		case "client":
+			return list[i].ClientId < list[j].ClientId
+		default:
+			return list[i].Channel < list[j].Channel
+		}
+	})
+	return list
+}
+
+// Check returns an ExitError when a client group lag is over the max lag, or a client is stalled and stalled clients fail the check
+func (o *LagOptions) Check(clients []*ClientLag) error {
+	SetGroupLags(clients)
+	over, stalled := 0, 0
+	for _, c := range clients {
+		if o.MaxLag > 0 && c.GroupLag > o.MaxLag {
+			over++
+		}
+		if o.FailStalled && c.Stalled {
+			stalled++
+		}
+	}
+	if over == 0 && stalled == 0 {
+		return nil
+	}
+	var reasons []string
+	if over > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d clients over max lag %d", over, o.MaxLag))
+	}
+	if stalled > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d clients stalled", stalled))
+	}
+	return &utils.ExitError{
+		Code: LagExceededExitCode,
+		Err:  fmt.Errorf("lag check failed, %s", strings.Join(reasons, ", ")),
+	}
+}
+
+// PrintLagTab prints the lag report, the group columns are printed when a client belongs to a group
+func PrintLagTab(out io.Writer, clients []*ClientLag) {
+	grouped := false
+	for _, c := range clients {
+		if c.Group != "" {
+			grouped = true
+		}
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.TabIndent)
+	if grouped {
+		fmt.Fprintln(w, "CLIENT_ID\tGROUP\tCHANNEL\tACTIVE\tLAST_SEQUENCE\tLAST_SENT\tLAG\tGROUP_LAG\tPENDING\tSTALLED")
+	} else {
+		fmt.Fprintln(w, "CLIENT_ID\tCHANNEL\tACTIVE\tLAST_SEQUENCE\tLAST_SENT\tLAG\tPENDING\tSTALLED")
+	}
+	for _, c := range clients {
+		if grouped {
+			group := c.Group
+			if group == "" {
+				group = "N/A"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%d\t%d\t%d\t%d\t%d\t%t\n", c.ClientId, group, c.Channel, c.Active, c.LastSequence, c.LastSequenceSent, c.Lag, c.GroupLag, c.Pending, c.Stalled)
+		} else {
+			fmt.Fprintf(w, "%s\t%s\t%t\t%d\t%d\t%d\t%d\t%t\n", c.ClientId, c.Channel, c.Active, c.LastSequence, c.LastSequenceSent, c.Lag, c.Pending, c.Stalled)
+		}
+	}
+	fmt.Fprintf(w, "\nTOTAL CLIENTS:\t%d\n", len(clients))
+	w.Flush()
+}
@@ -0,0 +1,50 @@
+package kubemq
+
+import (
+	"testing"
+
+	"github.com/kubemq-io/kubemqctl/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLagOptions(t *testing.T) {
+	clients := []*ClientLag{
+		{Channel: "a", ClientId: "c1", Lag: 5},
+		{Channel: "b", ClientId: "c2", Lag: 50, Stalled: true},
+		{Channel: "c", ClientId: "c3", Lag: 0},
+	}
+	o := &LagOptions{MinLag: 1, Sort: "lag"}
+	require.NoError(t, o.Validate())
+	report := o.Report(clients)
+	require.Len(t, report, 2)
+	require.Equal(t, "c2", report[0].ClientId)
+	require.NoError(t, o.Check(clients))
+
+	o.MaxLag = 10
+	err := o.Check(clients)
+	require.Error(t, err)
+	require.Equal(t, LagExceededExitCode, err.(*utils.ExitError).Code)
+
+	o = &LagOptions{FailStalled: true, Sort: "channel"}
+	require.Error(t, o.Check(clients))
+	require.Error(t, (&LagOptions{Sort: "size"}).Validate())
+}
+
+func TestLagOptions_GroupLag(t *testing.T) {
+	clients := []*ClientLag{
+		{Channel: "a", ClientId: "c1", Group: "g1", Lag: 50},
+		{Channel: "a", ClientId: "c2", Group: "g1", Lag: 5},
+		{Channel: "b", ClientId: "c3", Group: "g1", Lag: 40},
+		{Channel: "a", ClientId: "c4", Lag: 30},
+	}
+	SetGroupLags(clients)
+	require.Equal(t, int64(5), clients[0].GroupLag)
+	require.Equal(t, int64(5), clients[1].GroupLag)
+	require.Equal(t, int64(40), clients[2].GroupLag)
+	require.Equal(t, int64(30), clients[3].GroupLag)
+
+	o := &LagOptions{MaxLag: 45, Sort: "lag"}
+	require.NoError(t, o.Check(clients))
+	o.MaxLag = 35
+	require.EqualError(t, o.Check(clients).(*utils.ExitError).Err, "lag check failed, 1 clients over max lag 35")
+}
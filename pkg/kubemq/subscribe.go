@@ -0,0 +1,20 @@
+package kubemq
+
+import (
+	"context"
+
+	"github.com/kubemq-io/kubemqctl/pkg/utils"
+)
+
+// Subscribe runs subscribe until ctx is done. With reconnect, a subscription which ends before ctx is done is subscribed again after an exponential backoff delay, unless it ends with a utils.StopReconnect error
+func Subscribe(ctx context.Context, name string, reconnect bool, subscribe func(ctx context.Context) error) error {
+	if !reconnect {
+		return subscribe(ctx)
+	}
+	return utils.Reconnect(ctx, name, func(ctx context.Context) error {
+		// cancel each attempt context so the disconnected subscription resources are released
+		subCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		return subscribe(subCtx)
+	})
+}
@@ -0,0 +1,155 @@
+package kubemq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kubemq-io/kubemqctl/pkg/output"
+	"github.com/mattn/go-isatty"
+)
+
+// ChannelStats is a stats API snapshot of a channel, clients are mapped to their pending messages
+type ChannelStats struct {
+	Name         string
+	Messages     int64
+	Bytes        int64
+	LastSequence int64
+	Clients      map[string]int64
+}
+
+// ChannelRates is the change of a channel between two snapshots
+type ChannelRates struct {
+	Channel        string   `json:"channel"`
+	Messages       int64    `json:"messages"`
+	Bytes          int64    `json:"bytes"`
+	MessagesPerSec float64  `json:"messages_per_sec"`
+	BytesPerSec    float64  `json:"bytes_per_sec"`
+	Clients        int      `json:"clients"`
+	Pending        int64    `json:"pending"`
+	PendingDelta   int64    `json:"pending_delta"`
+	NewClients     []string `json:"new_clients,omitempty"`
+	GoneClients    []string `json:"gone_clients,omitempty"`
+}
+
+type WatchSnapshot struct {
+	Time     time.Time       `json:"time"`
+	Channels []*ChannelRates `json:"channels"`
+}
+
+// Rates compares the current snapshot to the previous one, messages per second is the rate of new sequences and bytes per second is the rate of stored bytes change
+func Rates(prev, curr []*ChannelStats, elapsed time.Duration) []*ChannelRates {
+	prevIndex := map[string]*ChannelStats{}
+	for _, ch := range prev {
+		prevIndex[ch.Name] = ch
+	}
+	var list []*ChannelRates
+	for _, ch := range curr {
+		r := &ChannelRates{
+			Channel:  ch.Name,
+			Messages: ch.Messages,
+			Bytes:    ch.Bytes,
+			Clients:  len(ch.Clients),
+		}
+		for _, pending := range ch.Clients {
+			r.Pending += pending
+		}
+		p, ok := prevIndex[ch.Name]
+		if !ok {
+			p = &ChannelStats{Name: ch.Name, Messages: ch.Messages, Bytes: ch.Bytes, LastSequence: ch.LastSequence}
+		}
+		var prevPending int64
+		for client, pending := range p.Clients {
+			prevPending += pending
+			if _, ok := ch.Clients[client]; !ok {
+				r.GoneClients = append(r.GoneClients, client)
+			}
+		}
+		for client := range ch.Clients {
+			if _, found := p.Clients[client]; !found && prev != nil {
+				r.NewClients = append(r.NewClients, client)
+			}
+		}
+		if ok {
+			r.PendingDelta = r.Pending - prevPending
+		}
+		if seconds := elapsed.Seconds(); seconds > 0 {
+			r.MessagesPerSec = float64(ch.LastSequence-p.LastSequence) / seconds
+			r.BytesPerSec = float64(ch.Bytes-p.Bytes) / seconds
+		}
+		sort.Strings(r.NewClients)
+		sort.Strings(r.GoneClients)
+		list = append(list, r)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Channel < list[j].Channel
+	})
+	return list
+}
+
+// Watch polls the stats every interval until ctx is done, a terminal is refreshed in place with the rates table, otherwise each snapshot is written as a json line or in the structured output format
+func Watch(ctx context.Context, interval time.Duration, fetch func() ([]*ChannelStats, error)) error {
+	inPlace := output.IsText() && isatty.IsTerminal(os.Stdout.Fd())
+	var prev []*ChannelStats
+	var prevTime time.Time
+	for {
+		curr, err := fetch()
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		var elapsed time.Duration
+		if prev != nil {
+			elapsed = now.Sub(prevTime)
+		}
+		snapshot := &WatchSnapshot{
+			Time:     now.UTC(),
+			Channels: Rates(prev, curr, elapsed),
+		}
+		switch {
+		case inPlace:
+			// move the cursor home and clear the screen
+			fmt.Fprint(os.Stdout, "\033[H\033[2J")
+			fmt.Fprintf(os.Stdout, "Every %s: %s\n\n", interval, now.Format("2006-01-02 15:04:05"))
+			PrintRatesTab(os.Stdout, snapshot.Channels)
+		case output.IsText():
+			data, err := json.Marshal(snapshot)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(os.Stdout, string(data))
+		default:
+			if err := output.Stream(snapshot); err != nil {
+				return err
+			}
+		}
+		prev, prevTime = curr, now
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func PrintRatesTab(out io.Writer, channels []*ChannelRates) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.TabIndent)
+	fmt.Fprintln(w, "CHANNEL\tMESSAGES\tMSGS/SEC\tBYTES\tBYTES/SEC\tCLIENTS\tPENDING\tPENDING_DELTA")
+	for _, r := range channels {
+		fmt.Fprintf(w, "%s\t%d\t%.1f\t%d\t%.1f\t%d\t%d\t%+d\n", r.Channel, r.Messages, r.MessagesPerSec, r.Bytes, r.BytesPerSec, r.Clients, r.Pending, r.PendingDelta)
+	}
+	w.Flush()
+	for _, r := range channels {
+		for _, client := range r.NewClients {
+			fmt.Fprintf(out, "+ client %s joined %s\n", client, r.Channel)
+		}
+		for _, client := range r.GoneClients {
+			fmt.Fprintf(out, "- client %s left %s\n", client, r.Channel)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package kubemq
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRates(t *testing.T) {
+	prev := []*ChannelStats{
+		{Name: "orders", Bytes: 100, LastSequence: 10, Clients: map[string]int64{"c1": 2, "c2": 0}},
+	}
+	curr := []*ChannelStats{
+		{Name: "orders", Bytes: 300, LastSequence: 30, Clients: map[string]int64{"c1": 5, "c3": 1}},
+		{Name: "payments", LastSequence: 5, Clients: map[string]int64{"c4": 0}},
+	}
+	rates := Rates(prev, curr, 2*time.Second)
+	require.Len(t, rates, 2)
+	orders := rates[0]
+	require.Equal(t, 10.0, orders.MessagesPerSec)
+	require.Equal(t, 100.0, orders.BytesPerSec)
+	require.Equal(t, int64(6), orders.Pending)
+	require.Equal(t, int64(4), orders.PendingDelta)
+	require.Equal(t, []string{"c3"}, orders.NewClients)
+	require.Equal(t, []string{"c2"}, orders.GoneClients)
+	require.Equal(t, []string{"c4"}, rates[1].NewClients)
+	require.Equal(t, 0.0, rates[1].MessagesPerSec)
+
+	first := Rates(nil, curr, 0)
+	require.Empty(t, first[0].NewClients)
+}
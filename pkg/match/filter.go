@@ -0,0 +1,484 @@
+package match
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Message is the message a filter expression is evaluated on
+type Message struct {
+	Id       string
+	Channel  string
+	ClientId string
+	Metadata string
+	Tags     map[string]string
+	Body     []byte
+}
+
+// filterFields are the message fields of filter expression paths, body and tags paths select json body fields and tags, i.e. body.order.total and tags.region
+var filterFields = []string{"id", "channel", "client_id", "metadata", "tags", "body"}
+
+// Filter is a parsed filter expression, i.e. body.order.total > 100 && tags.region == "eu".
+// Expressions compare message fields and literals (numbers, "strings", 'strings', true, false and null) with ==, !=, >, >=, <, <=,
+// =~ and !~ (regex), and combine conditions with &&, ||, ! and parentheses. Missing fields are null, a field alone is true when it is set.
+type Filter struct {
+	expr string
+	root node
+}
+
+// ParseFilter parses a filter expression, an empty expression returns a nil filter which matches any message
+func ParseFilter(expr string) (*Filter, error) {
+	if strings.TrimSpace(expr) == "" {
+		return nil, nil
+	}
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter %s, %s", expr, err.Error())
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err == nil && p.peek().kind != tokenEnd {
+		err = fmt.Errorf("unexpected %s at position %d", p.peek().text, p.peek().pos)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter %s, %s", expr, err.Error())
+	}
+	return &Filter{
+		expr: expr,
+		root: root,
+	}, nil
+}
+
+func (f *Filter) String() string {
+	return f.expr
+}
+
+func (f *Filter) Match(msg *Message) bool {
+	if f == nil {
+		return true
+	}
+	return truthy(f.root.eval(&env{msg: msg}))
+}
+
+// env evaluates the paths of one message, the body is parsed as json once and only when a body path is used
+type env struct {
+	msg    *Message
+	parsed bool
+	body   interface{}
+}
+
+func (e *env) jsonBody() interface{} {
+	if !e.parsed {
+		e.parsed = true
+		if err := json.Unmarshal(e.msg.Body, &e.body); err != nil {
+			e.body = nil
+		}
+	}
+	return e.body
+}
+
+type node interface {
+	eval(e *env) interface{}
+}
+
+type literalNode struct {
+	value interface{}
+}
+
+func (n *literalNode) eval(e *env) interface{} {
+	return n.value
+}
+
+type pathNode struct {
+	field string
+	keys  []string
+}
+
+func (n *pathNode) eval(e *env) interface{} {
+	switch n.field {
+	case "id":
+		return e.msg.Id
+	case "channel":
+		return e.msg.Channel
+	case "client_id":
+		return e.msg.ClientId
+	case "metadata":
+		return e.msg.Metadata
+	case "tags":
+		if len(n.keys) == 0 {
+			tags := map[string]interface{}{}
+			for key, value := range e.msg.Tags {
+				tags[key] = value
+			}
+			return tags
+		}
+		if value, ok := e.msg.Tags[strings.Join(n.keys, ".")]; ok {
+			return value
+		}
+		return nil
+	default:
+		if len(n.keys) == 0 {
+			if body := e.jsonBody(); body != nil {
+				return body
+			}
+			return string(e.msg.Body)
+		}
+		value, _ := jsonFields(e.jsonBody(), n.keys)
+		return value
+	}
+}
+
+type notNode struct {
+	x node
+}
+
+func (n *notNode) eval(e *env) interface{} {
+	return !truthy(n.x.eval(e))
+}
+
+type logicalNode struct {
+	op   string
+	x, y node
+}
+
+func (n *logicalNode) eval(e *env) interface{} {
+	if n.op == "&&" {
+		return truthy(n.x.eval(e)) && truthy(n.y.eval(e))
+	}
+	return truthy(n.x.eval(e)) || truthy(n.y.eval(e))
+}
+
+type compareNode struct {
+	op   string
+	x, y node
+	rex  *regexp.Regexp
+}
+
+func (n *compareNode) eval(e *env) interface{} {
+	a := n.x.eval(e)
+	switch n.op {
+	case "=~", "!~":
+		matched := a != nil && n.rex.MatchString(text(a))
+		return matched == (n.op == "=~")
+	case "==":
+		return equal(a, n.y.eval(e))
+	case "!=":
+		return !equal(a, n.y.eval(e))
+	}
+	b := n.y.eval(e)
+	var c int
+	na, aOk := number(a)
+	nb, bOk := number(b)
+	sa, aStr := a.(string)
+	sb, bStr := b.(string)
+	switch {
+	case aOk && bOk:
+		switch {
+		case na < nb:
+			c = -1
+		case na > nb:
+			c = 1
+		}
+	case aStr && bStr:
+		c = strings.Compare(sa, sb)
+	default:
+		return false
+	}
+	switch n.op {
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	case "<":
+		return c < 0
+	default:
+		return c <= 0
+	}
+}
+
+// number converts json numbers and numeric strings, such as tag values, to float64
+func number(v interface{}) (float64, bool) {
+	switch value := v.(type) {
+	case float64:
+		return value, true
+	case string:
+		n, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		return n, err == nil
+	}
+	return 0, false
+}
+
+func equal(a, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	_, aNum := a.(float64)
+	_, bNum := b.(float64)
+	if aNum || bNum {
+		na, aOk := number(a)
+		nb, bOk := number(b)
+		return aOk && bOk && na == nb
+	}
+	return text(a) == text(b)
+}
+
+// text returns strings as is and other values as json
+func text(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	data, _ := json.Marshal(v)
+	return string(data)
+}
+
+func truthy(v interface{}) bool {
+	switch value := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return value
+	case float64:
+		return value != 0
+	case string:
+		return value != ""
+	case map[string]interface{}:
+		return len(value) > 0
+	case []interface{}:
+		return len(value) > 0
+	}
+	return true
+}
+
+const (
+	tokenEnd = iota
+	tokenPath
+	tokenNumber
+	tokenString
+	tokenOp
+)
+
+type token struct {
+	kind  int
+	text  string
+	value interface{}
+	pos   int
+}
+
+var operators = []string{"&&", "||", "==", "!=", ">=", "<=", "=~", "!~", ">", "<", "!", "(", ")"}
+
+func tokenize(expr string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(expr); {
+		c := rune(expr[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '"' || c == '\'':
+			end := i + 1
+			for end < len(expr) && expr[end] != byte(c) {
+				if expr[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(expr) {
+				return nil, fmt.Errorf("unterminated string at position %d", i)
+			}
+			raw := expr[i : end+1]
+			if c == '\'' {
+				raw = `"` + strings.Replace(strings.Replace(raw[1:len(raw)-1], `\'`, `'`, -1), `"`, `\"`, -1) + `"`
+			}
+			value, err := strconv.Unquote(raw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid string at position %d", i)
+			}
+			tokens = append(tokens, token{kind: tokenString, text: expr[i : end+1], value: value, pos: i})
+			i = end + 1
+		case unicode.IsDigit(c) || c == '-' && i+1 < len(expr) && unicode.IsDigit(rune(expr[i+1])):
+			end := i + 1
+			for end < len(expr) && strings.ContainsRune("0123456789.eE+-", rune(expr[end])) {
+				end++
+			}
+			n, err := strconv.ParseFloat(expr[i:end], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %s at position %d", expr[i:end], i)
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: expr[i:end], value: n, pos: i})
+			i = end
+		case unicode.IsLetter(c) || c == '_':
+			end := i + 1
+			for end < len(expr) && isPathChar(rune(expr[end])) {
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenPath, text: expr[i:end], pos: i})
+			i = end
+		default:
+			op := ""
+			for _, o := range operators {
+				if strings.HasPrefix(expr[i:], o) {
+					op = o
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unexpected %c at position %d", c, i)
+			}
+			tokens = append(tokens, token{kind: tokenOp, text: op, pos: i})
+			i += len(op)
+		}
+	}
+	return append(tokens, token{kind: tokenEnd, text: "end of filter", pos: len(expr)}), nil
+}
+
+// isPathChar returns true for the characters of a field path, tag keys and json fields may contain - as well
+func isPathChar(c rune) bool {
+	return unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_' || c == '-' || c == '.'
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEnd {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) isOp(ops ...string) bool {
+	t := p.peek()
+	if t.kind != tokenOp {
+		return false
+	}
+	for _, op := range ops {
+		if t.text == op {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) parseOr() (node, error) {
+	x, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("||") {
+		p.next()
+		y, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		x = &logicalNode{op: "||", x: x, y: y}
+	}
+	return x, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	x, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("&&") {
+		p.next()
+		y, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		x = &logicalNode{op: "&&", x: x, y: y}
+	}
+	return x, nil
+}
+
+func (p *parser) parseNot() (node, error) {
+	if p.isOp("!") {
+		p.next()
+		x, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{x: x}, nil
+	}
+	return p.parseCompare()
+}
+
+func (p *parser) parseCompare() (node, error) {
+	x, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	if !p.isOp("==", "!=", ">", ">=", "<", "<=", "=~", "!~") {
+		return x, nil
+	}
+	op := p.next()
+	y, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	n := &compareNode{op: op.text, x: x, y: y}
+	if op.text == "=~" || op.text == "!~" {
+		lit, ok := y.(*literalNode)
+		str, isStr := "", false
+		if ok {
+			str, isStr = lit.value.(string)
+		}
+		if !isStr {
+			return nil, fmt.Errorf("%s at position %d must be followed by a regex string", op.text, op.pos)
+		}
+		if n.rex, err = regexp.Compile(str); err != nil {
+			return nil, fmt.Errorf("invalid regex at position %d, %s", op.pos, err.Error())
+		}
+	}
+	return n, nil
+}
+
+func (p *parser) parseOperand() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenNumber, tokenString:
+		return &literalNode{value: t.value}, nil
+	case tokenPath:
+		switch t.text {
+		case "true":
+			return &literalNode{value: true}, nil
+		case "false":
+			return &literalNode{value: false}, nil
+		case "null":
+			return &literalNode{value: nil}, nil
+		}
+		keys := strings.Split(t.text, ".")
+		for _, field := range filterFields {
+			if keys[0] == field {
+				if len(keys) > 1 && field != "tags" && field != "body" {
+					return nil, fmt.Errorf("%s at position %d has no fields", field, t.pos)
+				}
+				return &pathNode{field: field, keys: keys[1:]}, nil
+			}
+		}
+		return nil, fmt.Errorf("unknown field %s at position %d, fields are %s", keys[0], t.pos, strings.Join(filterFields, ", "))
+	case tokenOp:
+		if t.text == "(" {
+			x, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if !p.isOp(")") {
+				return nil, fmt.Errorf("missing ) at position %d", p.peek().pos)
+			}
+			p.next()
+			return x, nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected %s at position %d", t.text, t.pos)
+}
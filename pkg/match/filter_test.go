@@ -0,0 +1,75 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilter(t *testing.T) {
+	msg := &Message{
+		Id:       "m1",
+		Channel:  "orders",
+		ClientId: "client-1",
+		Metadata: "v2",
+		Tags:     map[string]string{"region": "eu", "priority": "5", "trace-id": "abc"},
+		Body:     []byte(`{"order":{"total":150.5,"paid":true,"items":[{"sku":"a-1"}]},"note":null}`),
+	}
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{`body.order.total > 100 && tags.region == "eu"`, true},
+		{`body.order.total > 200 || tags.region == 'us'`, false},
+		{`body.order.total >= 150.5 && body.order.total <= 150.5`, true},
+		{`body.order.paid`, true},
+		{`!body.order.paid`, false},
+		{`body.order.missing == null`, true},
+		{`body.note == null`, true},
+		{`body.order.items.0.sku == "a-1"`, true},
+		{`tags.priority > 3`, true},
+		{`tags.priority == 5`, true},
+		{`tags.trace-id =~ "^ab"`, true},
+		{`tags.missing =~ "."`, false},
+		{`tags.missing !~ "."`, true},
+		{`tags.missing`, false},
+		{`channel == "orders" && client_id == "client-1" && id == "m1" && metadata != "v1"`, true},
+		{`!(metadata == "v2" || channel == "x")`, false},
+		{`body =~ "total"`, true},
+		{`metadata > "v1"`, true},
+		{`body.order.total > "abc"`, false},
+		{`tags.region == -1`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			f, err := ParseFilter(tt.expr)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, f.Match(msg))
+		})
+	}
+
+	f, err := ParseFilter(`body.total > 1`)
+	require.NoError(t, err)
+	require.False(t, f.Match(&Message{Body: []byte("not json")}))
+
+	f, err = ParseFilter(" ")
+	require.NoError(t, err)
+	require.True(t, f.Match(msg))
+}
+
+func TestParseFilter_Errors(t *testing.T) {
+	for _, expr := range []string{
+		`body.total >`,
+		`(body.total > 1`,
+		`body.total > 1 tags.a`,
+		`size > 1`,
+		`metadata.a == "x"`,
+		`tags.a =~ 5`,
+		`tags.a =~ "("`,
+		`tags.a == "x`,
+		`tags.a # 1`,
+	} {
+		_, err := ParseFilter(expr)
+		require.Error(t, err, expr)
+	}
+}
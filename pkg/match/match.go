@@ -0,0 +1,138 @@
+package match
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Matcher matches a message by its metadata, tags, tags regex, body regex and json body fields, empty conditions match any message
+type Matcher struct {
+	Metadata  string            `json:"metadata"`
+	Tags      map[string]string `json:"tags"`
+	TagsRegex map[string]string `json:"tags_regex"`
+	Body      string            `json:"body"`
+	Json      map[string]string `json:"json"`
+	body      *regexp.Regexp
+	tagsRegex TagFilter
+}
+
+// Compile compiles the body and tags regexes, it must be called before Match
+func (m *Matcher) Compile() error {
+	tagsRegex, err := CompileTagFilter(m.TagsRegex)
+	if err != nil {
+		return err
+	}
+	m.tagsRegex = tagsRegex
+	if m.Body == "" {
+		return nil
+	}
+	rex, err := regexp.Compile(m.Body)
+	if err != nil {
+		return fmt.Errorf("invalid body regex, %s", err.Error())
+	}
+	m.body = rex
+	return nil
+}
+
+func (m *Matcher) Match(metadata string, tags map[string]string, body []byte) bool {
+	if m.Metadata != "" && m.Metadata != metadata {
+		return false
+	}
+	for key, value := range m.Tags {
+		if tag, ok := tags[key]; !ok || tag != value {
+			return false
+		}
+	}
+	if !m.tagsRegex.Match(tags) {
+		return false
+	}
+	if m.body != nil && !m.body.Match(body) {
+		return false
+	}
+	if len(m.Json) > 0 {
+		var obj interface{}
+		if err := json.Unmarshal(body, &obj); err != nil {
+			return false
+		}
+		for path, expected := range m.Json {
+			value, ok := JsonField(obj, path)
+			if !ok || fmt.Sprint(value) != expected {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// JsonField returns the value of a dot separated field path of a json object, array items are selected by index, i.e. order.id or items.0.sku
+func JsonField(obj interface{}, path string) (interface{}, bool) {
+	return jsonFields(obj, strings.Split(path, "."))
+}
+
+func jsonFields(obj interface{}, keys []string) (interface{}, bool) {
+	value := obj
+	for _, key := range keys {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			field, ok := v[key]
+			if !ok {
+				return nil, false
+			}
+			value = field
+		case []interface{}:
+			idx, err := strconv.Atoi(key)
+			if err != nil || idx < 0 || idx >= len(v) {
+				return nil, false
+			}
+			value = v[idx]
+		default:
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// TagFilter matches message tags by a regex per tag key, a message matches when it has all the tags and each tag value matches its regex
+type TagFilter map[string]*regexp.Regexp
+
+// ParseTagFilter parses tag filters in key=regex format
+func ParseTagFilter(filters []string) (TagFilter, error) {
+	tags := map[string]string{}
+	for _, filter := range filters {
+		idx := strings.Index(filter, "=")
+		if idx <= 0 {
+			return nil, fmt.Errorf("invalid tag filter %s, must be in key=regex format", filter)
+		}
+		tags[filter[:idx]] = filter[idx+1:]
+	}
+	return CompileTagFilter(tags)
+}
+
+// CompileTagFilter compiles a tag filter of tag keys and regexes
+func CompileTagFilter(tags map[string]string) (TagFilter, error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+	f := TagFilter{}
+	for key, value := range tags {
+		rex, err := regexp.Compile(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tag %s regex, %s", key, err.Error())
+		}
+		f[key] = rex
+	}
+	return f, nil
+}
+
+func (f TagFilter) Match(tags map[string]string) bool {
+	for key, rex := range f {
+		tag, ok := tags[key]
+		if !ok || !rex.MatchString(tag) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,48 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatcher(t *testing.T) {
+	m := &Matcher{
+		Metadata: "order",
+		Tags:     map[string]string{"type": "express"},
+		Body:     `"id"`,
+		Json:     map[string]string{"order.id": "7", "order.paid": "true"},
+	}
+	require.NoError(t, m.Compile())
+	body := []byte(`{"order":{"id":7,"paid":true}}`)
+	require.True(t, m.Match("order", map[string]string{"type": "express", "region": "eu"}, body))
+	require.False(t, m.Match("other", map[string]string{"type": "express"}, body))
+	require.False(t, m.Match("order", map[string]string{"type": "slow"}, body))
+	require.False(t, m.Match("order", map[string]string{"type": "express"}, []byte(`{"order":{"id":8,"paid":true}}`)))
+	require.False(t, m.Match("order", map[string]string{"type": "express"}, []byte(`not json "id"`)))
+
+	require.True(t, (&Matcher{}).Match("", nil, nil))
+	require.Error(t, (&Matcher{Body: "("}).Compile())
+}
+
+func TestTagFilter(t *testing.T) {
+	f, err := ParseTagFilter([]string{"region=^eu-", "trace=.+"})
+	require.NoError(t, err)
+	require.True(t, f.Match(map[string]string{"region": "eu-west", "trace": "abc"}))
+	require.False(t, f.Match(map[string]string{"region": "us-east", "trace": "abc"}))
+	require.False(t, f.Match(map[string]string{"region": "eu-west"}))
+
+	f, err = ParseTagFilter(nil)
+	require.NoError(t, err)
+	require.True(t, f.Match(nil))
+
+	_, err = ParseTagFilter([]string{"region"})
+	require.Error(t, err)
+	_, err = ParseTagFilter([]string{"region=("})
+	require.Error(t, err)
+
+	m := &Matcher{TagsRegex: map[string]string{"region": "^eu-"}}
+	require.NoError(t, m.Compile())
+	require.True(t, m.Match("", map[string]string{"region": "eu-west"}, nil))
+	require.False(t, m.Match("", map[string]string{"region": "us-east"}, nil))
+}
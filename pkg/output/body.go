@@ -0,0 +1,165 @@
+package output
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/kubemq-io/kubemqctl/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	BodyAuto   = "auto"
+	BodyUtf8   = "utf8"
+	BodyBase64 = "base64"
+	BodyHex    = "hex"
+)
+
+// BodyEncodings lists the supported values of the body encoding flag
+var BodyEncodings = []string{BodyAuto, BodyUtf8, BodyBase64, BodyHex}
+
+var bodyEncoding = BodyAuto
+
+// raw is set when message bodies are written as is instead of message objects
+var raw *rawWriter
+
+type rawWriter struct {
+	sync.Mutex
+	out       io.Writer
+	delimiter []byte
+}
+
+func (w *rawWriter) write(body []byte) {
+	w.Lock()
+	defer w.Unlock()
+	data := make([]byte, 0, len(body)+len(w.delimiter))
+	data = append(append(data, body...), w.delimiter...)
+	if _, err := w.out.Write(data); err != nil {
+		fmt.Fprintf(os.Stderr, "error: output message body, %s\n", err.Error())
+	}
+}
+
+// BodyOptions are the flags of the receive commands which set how message bodies are printed
+type BodyOptions struct {
+	Raw       bool
+	Delimiter string
+	Encoding  string
+}
+
+func (o *BodyOptions) AddFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().BoolVarP(&o.Raw, "raw", "", false, "set print only the message bodies, each body is followed by the delimiter")
+	cmd.PersistentFlags().StringVarP(&o.Delimiter, "delimiter", "", `\n`, `set delimiter written after each body with --raw, escapes such as \n, \t and \0 are supported`)
+	cmd.PersistentFlags().StringVarP(&o.Encoding, "body-encoding", "", BodyAuto, fmt.Sprintf("set message body encoding, one of %s, auto prints json and utf8 bodies as is and other bodies as base64", strings.Join(BodyEncodings, ", ")))
+}
+
+// Complete sets the body encoding and raw output, status messages of the receive commands always go to stderr so the messages can be piped
+func (o *BodyOptions) Complete() error {
+	switch o.Encoding {
+	case BodyAuto, BodyUtf8, BodyBase64, BodyHex:
+	default:
+		return fmt.Errorf("invalid body encoding %s, supported encodings: %s", o.Encoding, strings.Join(BodyEncodings, ", "))
+	}
+	bodyEncoding = o.Encoding
+	utils.SetOutput(os.Stderr)
+	if !o.Raw {
+		return nil
+	}
+	if !IsText() {
+		return fmt.Errorf("--raw cannot be set with the output flag")
+	}
+	delimiter, err := ParseDelimiter(o.Delimiter)
+	if err != nil {
+		return err
+	}
+	raw = &rawWriter{
+		out:       current.out,
+		delimiter: []byte(delimiter),
+	}
+	return nil
+}
+
+// ParseDelimiter interprets the escape sequences of a delimiter, \0 is the nul delimiter
+func ParseDelimiter(value string) (string, error) {
+	if value == `\0` {
+		return "\x00", nil
+	}
+	delimiter, err := strconv.Unquote(`"` + strings.Replace(value, `"`, `\"`, -1) + `"`)
+	if err != nil {
+		return "", fmt.Errorf("invalid delimiter %s, %s", value, err.Error())
+	}
+	return delimiter, nil
+}
+
+// IsRaw returns true when only message bodies are printed
+func IsRaw() bool {
+	return raw != nil
+}
+
+// Raw writes a message body followed by the delimiter
+func Raw(body []byte) {
+	raw.write(NewBody(body).rawBody())
+}
+
+// Body is the body of a printed message object, json and utf8 bodies are kept as is and other bodies are encoded by the body encoding.
+// Message objects embed Body, so in raw output the message is written as its body only.
+type Body struct {
+	BodyJson     json.RawMessage `json:"body_json,omitempty"`
+	BodyString   string          `json:"body_string,omitempty"`
+	BodyEncoding string          `json:"body_encoding,omitempty"`
+	raw          []byte
+}
+
+func NewBody(body []byte) Body {
+	b := Body{
+		raw: body,
+	}
+	switch bodyEncoding {
+	case BodyUtf8:
+		b.BodyString = string(body)
+	case BodyBase64:
+		b.BodyString = base64.StdEncoding.EncodeToString(body)
+		b.BodyEncoding = BodyBase64
+	case BodyHex:
+		b.BodyString = hex.EncodeToString(body)
+		b.BodyEncoding = BodyHex
+	default:
+		switch {
+		case json.Valid(body):
+			b.BodyJson = body
+		case utf8.Valid(body):
+			b.BodyString = string(body)
+		default:
+			b.BodyString = base64.StdEncoding.EncodeToString(body)
+			b.BodyEncoding = BodyBase64
+		}
+	}
+	return b
+}
+
+// Text returns the body as printed in a single line of text
+func (b Body) Text() string {
+	if len(b.BodyJson) > 0 {
+		return string(b.BodyJson)
+	}
+	return b.BodyString
+}
+
+// rawBody returns the body bytes as is, unless the body encoding is base64 or hex
+func (b Body) rawBody() []byte {
+	if bodyEncoding == BodyBase64 || bodyEncoding == BodyHex {
+		return []byte(b.BodyString)
+	}
+	return b.raw
+}
+
+type bodyMessage interface {
+	rawBody() []byte
+}
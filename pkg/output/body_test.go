@@ -0,0 +1,77 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testMessage struct {
+	Id string `json:"id"`
+	Body
+}
+
+func (m *testMessage) String() string {
+	return m.Id
+}
+
+type testResult struct {
+	Id string `json:"id"`
+}
+
+func (r *testResult) String() string {
+	return r.Id
+}
+
+func TestNewBody(t *testing.T) {
+	defer func() {
+		bodyEncoding = BodyAuto
+	}()
+	tests := []struct {
+		name     string
+		encoding string
+		body     []byte
+		want     Body
+	}{
+		{"auto json", BodyAuto, []byte(`{"a":1}`), Body{BodyJson: []byte(`{"a":1}`)}},
+		{"auto text", BodyAuto, []byte("some text"), Body{BodyString: "some text"}},
+		{"auto base64 like text is not decoded", BodyAuto, []byte("aGVsbG8="), Body{BodyString: "aGVsbG8="}},
+		{"auto binary", BodyAuto, []byte{0xff, 0x00}, Body{BodyString: "/wA=", BodyEncoding: BodyBase64}},
+		{"utf8 json", BodyUtf8, []byte(`{"a":1}`), Body{BodyString: `{"a":1}`}},
+		{"base64", BodyBase64, []byte("hello"), Body{BodyString: "aGVsbG8=", BodyEncoding: BodyBase64}},
+		{"hex", BodyHex, []byte("hello"), Body{BodyString: "68656c6c6f", BodyEncoding: BodyHex}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bodyEncoding = tt.encoding
+			got := NewBody(tt.body)
+			tt.want.raw = tt.body
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBodyOptions(t *testing.T) {
+	defer func() {
+		bodyEncoding = BodyAuto
+		raw = nil
+	}()
+	require.Error(t, (&BodyOptions{Encoding: "utf16"}).Complete())
+	require.Error(t, (&BodyOptions{Encoding: BodyAuto, Raw: true, Delimiter: `\q`}).Complete())
+	require.NoError(t, (&BodyOptions{Encoding: BodyAuto, Raw: true, Delimiter: `\0`}).Complete())
+	require.True(t, IsRaw())
+
+	buf := &bytes.Buffer{}
+	raw.out = buf
+	Message(&testMessage{Id: "1", Body: NewBody([]byte("first\nline"))})
+	Message(&testMessage{Id: "2", Body: NewBody([]byte{0xff, 0x00})})
+	Message(&testResult{Id: "3"})
+	require.Equal(t, "first\nline\x00\xff\x00\x00", buf.String())
+
+	buf.Reset()
+	require.NoError(t, (&BodyOptions{Encoding: BodyHex, Raw: true, Delimiter: `\n`}).Complete())
+	raw.out = buf
+	Message(&testMessage{Id: "1", Body: NewBody([]byte("hi"))})
+	require.Equal(t, "6869\n", buf.String())
+}
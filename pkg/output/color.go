@@ -0,0 +1,56 @@
+package output
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sync"
+
+	"github.com/fatih/color"
+)
+
+var tagColors = []*color.Color{
+	color.New(color.FgHiCyan),
+	color.New(color.FgHiGreen),
+	color.New(color.FgHiMagenta),
+	color.New(color.FgHiYellow),
+	color.New(color.FgHiBlue),
+	color.New(color.FgHiRed),
+}
+
+// SetColor enables or disables colored tags, colors are disabled anyway when stdout is not a terminal
+func SetColor(enabled bool) {
+	if !enabled {
+		color.NoColor = true
+	}
+}
+
+// Tag returns the name in brackets, colored by a hash of the name so each name keeps its color
+func Tag(name string) string {
+	hash := fnv.New32()
+	hash.Write([]byte(name)) //nolint
+	c := tagColors[hash.Sum32()%uint32(len(tagColors))]
+	return c.Sprint(fmt.Sprintf("[%s]", name))
+}
+
+// TaggedPrinter serializes the messages of concurrent subscriptions, in text output the messages of a tagged printer start with their channel tag
+type TaggedPrinter struct {
+	sync.Mutex
+	tagged bool
+}
+
+func NewTaggedPrinter(tagged bool) *TaggedPrinter {
+	return &TaggedPrinter{
+		tagged: tagged,
+	}
+}
+
+// Message writes a message object of channel, see Message
+func (p *TaggedPrinter) Message(channel string, obj fmt.Stringer) {
+	p.Lock()
+	defer p.Unlock()
+	if p.tagged && IsText() && !IsRaw() {
+		fmt.Fprintln(current.out, Tag(channel), obj.String())
+		return
+	}
+	Message(obj)
+}
@@ -0,0 +1,183 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/sprig"
+	"github.com/ghodss/yaml"
+	"github.com/kubemq-io/kubemqctl/pkg/utils"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+const (
+	FormatText       = ""
+	FormatWide       = "wide"
+	FormatJson       = "json"
+	FormatYaml       = "yaml"
+	FormatJsonPath   = "jsonpath"
+	FormatGoTemplate = "go-template"
+)
+
+// Formats lists the supported values of the global output flag
+var Formats = []string{FormatJson, FormatYaml, FormatWide, FormatJsonPath + "=...", FormatGoTemplate + "=..."}
+
+type printer struct {
+	format   string
+	jsonPath *jsonpath.JSONPath
+	tmpl     *template.Template
+	out      io.Writer
+}
+
+var current = &printer{
+	format: FormatText,
+	out:    os.Stdout,
+}
+
+// Set parses the global output flag value, any structured format moves the status messages to stderr
+func Set(value string) error {
+	p := &printer{
+		out: os.Stdout,
+	}
+	kind, arg := value, ""
+	if idx := strings.Index(value, "="); idx >= 0 {
+		kind, arg = value[:idx], value[idx+1:]
+	}
+	switch kind {
+	case FormatText, FormatWide, FormatJson, FormatYaml:
+		if arg != "" {
+			return fmt.Errorf("output format %s does not accept an argument", kind)
+		}
+	case FormatJsonPath:
+		if arg == "" {
+			return fmt.Errorf("missing jsonpath expression, i.e. -o jsonpath={.id}")
+		}
+		if !strings.Contains(arg, "{") {
+			arg = fmt.Sprintf("{%s}", arg)
+		}
+		p.jsonPath = jsonpath.New("output").AllowMissingKeys(true)
+		if err := p.jsonPath.Parse(arg); err != nil {
+			return fmt.Errorf("invalid jsonpath expression, %s", err.Error())
+		}
+	case FormatGoTemplate:
+		if arg == "" {
+			return fmt.Errorf("missing go-template, i.e. -o go-template={{.id}}")
+		}
+		tmpl, err := template.New("output").Funcs(sprig.TxtFuncMap()).Parse(arg)
+		if err != nil {
+			return fmt.Errorf("invalid go-template, %s", err.Error())
+		}
+		p.tmpl = tmpl
+	default:
+		return fmt.Errorf("invalid output format %s, supported formats: %s", value, strings.Join(Formats, ", "))
+	}
+	p.format = kind
+	current = p
+	if IsText() {
+		utils.SetOutput(os.Stdout)
+	} else {
+		utils.SetOutput(os.Stderr)
+	}
+	return nil
+}
+
+// IsText returns true when the output is a human readable format
+func IsText() bool {
+	return current.format == FormatText || current.format == FormatWide
+}
+
+// IsWide returns true when the output is the wide human readable format
+func IsWide() bool {
+	return current.format == FormatWide
+}
+
+// Print writes a single document in the selected structured format
+func Print(obj interface{}) error {
+	return current.write(obj, false)
+}
+
+// Stream writes one document of a stream in the selected structured format, json documents are written as NDJSON
+func Stream(obj interface{}) error {
+	return current.write(obj, true)
+}
+
+// Message writes a message object, text formats keep the message String representation.
+// Raw output writes the body of messages which embed Body, other objects are not written.
+func Message(obj fmt.Stringer) {
+	if IsRaw() {
+		if msg, ok := obj.(bodyMessage); ok {
+			raw.write(msg.rawBody())
+		}
+		return
+	}
+	if IsText() {
+		fmt.Fprintln(current.out, obj.String())
+		return
+	}
+	if err := Stream(obj); err != nil {
+		fmt.Fprintf(os.Stderr, "error: output message, %s\n", err.Error())
+	}
+}
+
+func (p *printer) write(obj interface{}, stream bool) error {
+	switch p.format {
+	case FormatYaml:
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		if stream {
+			_, err = fmt.Fprintf(p.out, "---\n%s", data)
+			return err
+		}
+		_, err = p.out.Write(data)
+		return err
+	case FormatJsonPath, FormatGoTemplate:
+		generic, err := toGeneric(obj)
+		if err != nil {
+			return err
+		}
+		buf := &bytes.Buffer{}
+		if p.jsonPath != nil {
+			err = p.jsonPath.Execute(buf, generic)
+		} else {
+			err = p.tmpl.Execute(buf, generic)
+		}
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(p.out, buf.String())
+		return err
+	default:
+		var data []byte
+		var err error
+		if stream {
+			data, err = json.Marshal(obj)
+		} else {
+			data, err = json.MarshalIndent(obj, "", "    ")
+		}
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(p.out, string(data))
+		return err
+	}
+}
+
+// toGeneric converts obj to its json representation so templates use the json field names
+func toGeneric(obj interface{}) (interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, err
+	}
+	return generic, nil
+}
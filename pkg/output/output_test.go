@@ -0,0 +1,95 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testObject struct {
+	Id   string            `json:"id"`
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+func TestSet(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{"text", "", false},
+		{"wide", "wide", false},
+		{"json", "json", false},
+		{"yaml", "yaml", false},
+		{"jsonpath", "jsonpath={.id}", false},
+		{"jsonpath relaxed", "jsonpath=.id", false},
+		{"go-template", "go-template={{.id}}", false},
+		{"json with argument", "json=x", true},
+		{"jsonpath missing expression", "jsonpath=", true},
+		{"go-template invalid", "go-template={{.id", true},
+		{"unknown", "xml", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Set(tt.value)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+	require.NoError(t, Set(""))
+}
+
+func TestWrite(t *testing.T) {
+	obj := &testObject{Id: "1", Tags: map[string]string{"region": "eu"}}
+	tests := []struct {
+		name   string
+		value  string
+		stream bool
+		want   string
+	}{
+		{"json stream", "json", true, "{\"id\":\"1\",\"tags\":{\"region\":\"eu\"}}\n"},
+		{"yaml stream", "yaml", true, "---\nid: \"1\"\ntags:\n  region: eu\n"},
+		{"jsonpath", "jsonpath={.tags.region}", false, "eu\n"},
+		{"go-template", "go-template={{.id}}-{{.tags.region | upper}}", false, "1-EU\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, Set(tt.value))
+			buf := &bytes.Buffer{}
+			current.out = buf
+			require.NoError(t, current.write(obj, tt.stream))
+			require.Equal(t, tt.want, buf.String())
+		})
+	}
+	require.NoError(t, Set(""))
+}
+
+func TestTaggedPrinter(t *testing.T) {
+	SetColor(false)
+	defer func() {
+		require.NoError(t, Set(""))
+	}()
+	tests := []struct {
+		name   string
+		value  string
+		tagged bool
+		want   string
+	}{
+		{"tagged text", "", true, "[orders] 1\n"},
+		{"untagged text", "", false, "1\n"},
+		{"tagged json", "json", true, "{\"id\":\"1\"}\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, Set(tt.value))
+			buf := &bytes.Buffer{}
+			current.out = buf
+			NewTaggedPrinter(tt.tagged).Message("orders", &testResult{Id: "1"})
+			require.Equal(t, tt.want, buf.String())
+		})
+	}
+}
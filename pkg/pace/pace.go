@@ -0,0 +1,220 @@
+package pace
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kubemq-io/kubemqctl/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// Options are the pacing flags of the send commands, messages are sent at a rate, for a duration or both
+type Options struct {
+	RateStr  string
+	Duration time.Duration
+	Burst    int
+	Jitter   float64
+	RampUp   time.Duration
+	Progress time.Duration
+	rate     float64
+}
+
+func (o *Options) AddFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&o.RateStr, "rate", "", "", "set sending rate in N/s, N/m or N/h format, empty sends as fast as possible")
+	cmd.PersistentFlags().DurationVarP(&o.Duration, "duration", "", 0, "set how long to send messages, messages are sent until the duration ends unless --messages is set")
+	cmd.PersistentFlags().IntVarP(&o.Burst, "burst", "", 1, "set how many messages to send back to back on each rate tick")
+	cmd.PersistentFlags().Float64VarP(&o.Jitter, "jitter", "", 0, "set random variation of the interval between sends, a fraction between 0 and 1")
+	cmd.PersistentFlags().DurationVarP(&o.RampUp, "ramp-up", "", 0, "set how long to ramp the rate up linearly to --rate")
+	cmd.PersistentFlags().DurationVarP(&o.Progress, "progress-interval", "", 5*time.Second, "set how often to print sending progress with --rate or --duration, 0 disables progress")
+}
+
+func (o *Options) Complete() error {
+	rand.Seed(time.Now().UnixNano())
+	if o.RateStr == "" {
+		return nil
+	}
+	rate, err := ParseRate(o.RateStr)
+	if err != nil {
+		return err
+	}
+	o.rate = rate
+	return nil
+}
+
+func (o *Options) Validate() error {
+	if o.Duration < 0 {
+		return fmt.Errorf("duration cannot be negative")
+	}
+	if o.Burst <= 0 {
+		return fmt.Errorf("burst must be greater than 0")
+	}
+	if o.Jitter < 0 || o.Jitter > 1 {
+		return fmt.Errorf("jitter must be between 0 and 1")
+	}
+	if o.RampUp < 0 {
+		return fmt.Errorf("ramp up cannot be negative")
+	}
+	if o.rate == 0 && (o.Burst > 1 || o.Jitter > 0 || o.RampUp > 0) {
+		return fmt.Errorf("--burst, --jitter and --ramp-up require --rate")
+	}
+	return nil
+}
+
+// IsPaced returns true when messages are sent at a rate or for a duration
+func (o *Options) IsPaced() bool {
+	return o.rate > 0 || o.Duration > 0
+}
+
+// Count returns how many messages to send, with a duration messages are sent until the duration ends unless the messages count flag is set
+func (o *Options) Count(messages int, messagesSet bool) int {
+	if o.Duration > 0 && !messagesSet {
+		return 0
+	}
+	return messages
+}
+
+// ParseRate parses a rate in N/s, N/m or N/h format to messages per second, a plain number is messages per second
+func ParseRate(str string) (float64, error) {
+	value, unit := str, "s"
+	if i := strings.Index(str, "/"); i >= 0 {
+		value, unit = str[:i], str[i+1:]
+	}
+	n, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid rate %s, must be a positive number in N/s, N/m or N/h format", str)
+	}
+	switch unit {
+	case "s":
+		return n, nil
+	case "m":
+		return n / 60, nil
+	case "h":
+		return n / 3600, nil
+	default:
+		return 0, fmt.Errorf("invalid rate unit %s, must be s, m or h", unit)
+	}
+}
+
+// interval returns the time to the next burst, the rate grows linearly during ramp up (at least 1 message per second) and the interval is randomized by the jitter fraction
+func (o *Options) interval(elapsed time.Duration) time.Duration {
+	rate := o.rate
+	if o.RampUp > 0 && elapsed < o.RampUp {
+		rate = math.Max(o.rate*float64(elapsed)/float64(o.RampUp), math.Min(o.rate, 1))
+	}
+	seconds := float64(o.Burst) / rate
+	if o.Jitter > 0 {
+		seconds *= 1 + o.Jitter*(2*rand.Float64()-1)
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// Stats counts the sent and failed messages of a paced run
+type Stats struct {
+	sync.Mutex
+	sent      int64
+	failed    int64
+	lastError string
+	start     time.Time
+}
+
+func (s *Stats) add(err error) {
+	s.Lock()
+	defer s.Unlock()
+	if err != nil {
+		s.failed++
+		s.lastError = err.Error()
+		return
+	}
+	s.sent++
+}
+
+func (s *Stats) snapshot() (int64, int64, string) {
+	s.Lock()
+	defer s.Unlock()
+	return s.sent, s.failed, s.lastError
+}
+
+// Run calls send for each message by the pacing options, count 0 sends until the duration ends.
+// Failed sends are counted and reported by the progress, so a soak test is not stopped by a single failure.
+func (o *Options) Run(ctx context.Context, count int, send func(ctx context.Context, i int) error) *Stats {
+	if o.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.Duration)
+		defer cancel()
+	}
+	s := &Stats{start: time.Now()}
+	if o.Progress > 0 {
+		done := make(chan struct{})
+		defer close(done)
+		go o.progress(s, done)
+	}
+	next := s.start
+	for i := 0; count == 0 || i < count; {
+		for b := 0; b < o.Burst && (count == 0 || i < count); b++ {
+			if ctx.Err() != nil {
+				return s
+			}
+			err := send(ctx, i)
+			if ctx.Err() != nil {
+				return s
+			}
+			s.add(err)
+			i++
+		}
+		if o.rate == 0 {
+			continue
+		}
+		now := time.Now()
+		interval := o.interval(now.Sub(s.start))
+		next = next.Add(interval)
+		// a send slower than the rate does not cause a catch up flood
+		if next.Before(now.Add(-interval)) {
+			next = now
+		}
+		select {
+		case <-time.After(time.Until(next)):
+		case <-ctx.Done():
+			return s
+		}
+	}
+	return s
+}
+
+func (o *Options) progress(s *Stats, done chan struct{}) {
+	ticker := time.NewTicker(o.Progress)
+	defer ticker.Stop()
+	var last int64
+	lastTime := s.start
+	for {
+		select {
+		case now := <-ticker.C:
+			sent, failed, lastErr := s.snapshot()
+			rate := float64(sent-last) / now.Sub(lastTime).Seconds()
+			last, lastTime = sent, now
+			if failed > 0 {
+				utils.Printlnf("sent %d messages, %d failed (last error: %s) in %s, %.1f msgs/sec", sent, failed, lastErr, now.Sub(s.start).Round(time.Second), rate)
+				continue
+			}
+			utils.Printlnf("sent %d messages in %s, %.1f msgs/sec", sent, now.Sub(s.start).Round(time.Second), rate)
+		case <-done:
+			return
+		}
+	}
+}
+
+// Report prints the totals and returns an error when some messages failed
+func (s *Stats) Report() error {
+	sent, failed, lastErr := s.snapshot()
+	elapsed := time.Since(s.start)
+	utils.Printlnf("%d messages sent, %d failed in %s, %.1f msgs/sec.", sent, failed, elapsed.Round(time.Millisecond), float64(sent)/elapsed.Seconds())
+	if failed > 0 {
+		return fmt.Errorf("%d messages failed to send, last error: %s", failed, lastErr)
+	}
+	return nil
+}
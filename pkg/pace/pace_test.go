@@ -0,0 +1,76 @@
+package pace
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRate(t *testing.T) {
+	tests := []struct {
+		str     string
+		want    float64
+		wantErr bool
+	}{
+		{str: "100", want: 100},
+		{str: "100/s", want: 100},
+		{str: "120/m", want: 2},
+		{str: "7200/h", want: 2},
+		{str: "0/s", wantErr: true},
+		{str: "abc", wantErr: true},
+		{str: "10/d", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseRate(tt.str)
+		if tt.wantErr {
+			require.Error(t, err, tt.str)
+			continue
+		}
+		require.NoError(t, err, tt.str)
+		require.Equal(t, tt.want, got, tt.str)
+	}
+}
+
+func TestOptions_Interval(t *testing.T) {
+	o := &Options{Burst: 5, rate: 10}
+	require.Equal(t, 500*time.Millisecond, o.interval(0))
+
+	o = &Options{Burst: 1, rate: 100, RampUp: 10 * time.Second}
+	require.Equal(t, time.Second, o.interval(0))
+	require.Equal(t, 20*time.Millisecond, o.interval(5*time.Second))
+	require.Equal(t, 10*time.Millisecond, o.interval(time.Minute))
+
+	o = &Options{Burst: 1, rate: 10, Jitter: 0.5}
+	for i := 0; i < 100; i++ {
+		d := o.interval(0)
+		require.True(t, d >= 50*time.Millisecond && d <= 150*time.Millisecond, d)
+	}
+}
+
+func TestOptions_Run(t *testing.T) {
+	o := &Options{Burst: 2, rate: 100}
+	var sent []int
+	start := time.Now()
+	s := o.Run(context.Background(), 5, func(ctx context.Context, i int) error {
+		sent = append(sent, i)
+		if i == 3 {
+			return fmt.Errorf("failed")
+		}
+		return nil
+	})
+	require.Equal(t, []int{0, 1, 2, 3, 4}, sent)
+	require.True(t, time.Since(start) >= 40*time.Millisecond)
+	require.Error(t, s.Report())
+
+	o = &Options{Burst: 1, rate: 50, Duration: 200 * time.Millisecond}
+	s = o.Run(context.Background(), 0, func(ctx context.Context, i int) error {
+		return nil
+	})
+	sentCount, failed, _ := s.snapshot()
+	require.InDelta(t, 10, sentCount, 2)
+	require.Zero(t, failed)
+	require.NoError(t, s.Report())
+}
@@ -3,21 +3,25 @@ package targets
 import (
 	"fmt"
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/kubemq-io/kubemqctl/pkg/utils"
 	"io/ioutil"
 	"strings"
 )
 
-func BuildRequest() ([]byte, error) {
-	fmt.Println("KubeMQ Target Request Builder:")
-	mdInput := ""
-	promptMetadata := &survey.Input{
-		Message: "Set Metadata Key/Value (key1=value1,key2=value2....)",
-		Default: "",
-		Help:    "Set Metadata Key/Value (key1=value1,key2=value2....)",
-	}
-	err := survey.AskOne(promptMetadata, &mdInput)
-	if err != nil {
-		return nil, err
+// BuildRequest builds a KubeMQ target request, metadata is prompted when not set and the data is prompted when neither metadata nor dataFile is set
+func BuildRequest(metadata, dataFile string) ([]byte, error) {
+	mdInput := metadata
+	if mdInput == "" {
+		fmt.Println("KubeMQ Target Request Builder:")
+		promptMetadata := &survey.Input{
+			Message: "Set Metadata Key/Value (key1=value1,key2=value2....)",
+			Default: "",
+			Help:    "Set Metadata Key/Value (key1=value1,key2=value2....)",
+		}
+		err := utils.AskOne(promptMetadata, &mdInput, "--build-metadata flag")
+		if err != nil {
+			return nil, err
+		}
 	}
 	params := strings.Split(mdInput, ",")
 	if len(params) == 0 {
@@ -31,6 +35,20 @@ func BuildRequest() ([]byte, error) {
 		}
 		md.Set(kv[0], kv[1])
 	}
+	if dataFile != "" || metadata != "" {
+		var data []byte
+		if dataFile != "" {
+			var err error
+			data, err = ioutil.ReadFile(dataFile)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return NewRequest().
+			SetMetadata(md).
+			SetData(data).
+			MarshalBinary(), nil
+	}
 	loadDataOptions := ""
 	loadDataSelectionPrompt := &survey.Select{
 		Renderer: survey.Renderer{},
@@ -39,7 +57,7 @@ func BuildRequest() ([]byte, error) {
 		Default:  "Empty Request data",
 		Help:     "Set KubeMQ Target Request data loading options",
 	}
-	err = survey.AskOne(loadDataSelectionPrompt, &loadDataOptions)
+	err := utils.AskOne(loadDataSelectionPrompt, &loadDataOptions, "--build-data flag")
 	if err != nil {
 		return nil, err
 	}
@@ -54,7 +72,7 @@ func BuildRequest() ([]byte, error) {
 			Default: "",
 			Help:    "Enter Request Data",
 		}
-		err := survey.AskOne(promptDataInput, &dataInput)
+		err := utils.AskOne(promptDataInput, &dataInput, "--build-data flag")
 		if err != nil {
 			return nil, err
 		}
@@ -66,7 +84,7 @@ func BuildRequest() ([]byte, error) {
 			Default: "",
 			Help:    "Enter Filename to load:",
 		}
-		err := survey.AskOne(promptDataFileName, &dataFileName)
+		err := utils.AskOne(promptDataFileName, &dataFileName, "--build-data flag")
 		if err != nil {
 			return nil, err
 		}
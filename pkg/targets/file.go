@@ -3,10 +3,15 @@ package targets
 import (
 	"fmt"
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/kubemq-io/kubemqctl/pkg/utils"
 	"io/ioutil"
 )
 
-func BuildFile() ([]byte, error) {
+// BuildFile loads data from fileName, the data source is prompted when fileName is not set
+func BuildFile(fileName string) ([]byte, error) {
+	if fileName != "" {
+		return ioutil.ReadFile(fileName)
+	}
 	loadFileOptions := ""
 	loadFileSelectionPrompt := &survey.Select{
 		Renderer: survey.Renderer{},
@@ -15,7 +20,7 @@ func BuildFile() ([]byte, error) {
 		Default:  "Open an Editor",
 		Help:     "Select Load data from file:",
 	}
-	err := survey.AskOne(loadFileSelectionPrompt, &loadFileOptions)
+	err := utils.AskOne(loadFileSelectionPrompt, &loadFileOptions, "file name argument")
 	if err != nil {
 		return nil, err
 	}
@@ -27,7 +32,7 @@ func BuildFile() ([]byte, error) {
 			Default: "",
 			Help:    "Copy & Paste data to editor:",
 		}
-		err := survey.AskOne(promptDataFromEditor, &dataFromEditor)
+		err := utils.AskOne(promptDataFromEditor, &dataFromEditor, "file name argument")
 		if err != nil {
 			return nil, err
 		}
@@ -39,7 +44,7 @@ func BuildFile() ([]byte, error) {
 			Default: "",
 			Help:    "Enter Filename to load:",
 		}
-		err := survey.AskOne(promptDataFileName, &dataFileName)
+		err := utils.AskOne(promptDataFileName, &dataFileName, "file name argument")
 		if err != nil {
 			return nil, err
 		}
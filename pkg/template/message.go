@@ -0,0 +1,263 @@
+package template
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Masterminds/sprig"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+// MessageOptions are the flags of the send commands which set the message tags and render the message body, metadata and tags for each message sent
+type MessageOptions struct {
+	BodyTemplate string
+	DataFile     string
+	Tags         []string
+	tags         map[string]string
+}
+
+func (o *MessageOptions) AddFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&o.BodyTemplate, "body-template", "", "", "set go template (with sprig functions) to render the body of each message, metadata is rendered as a template as well")
+	cmd.PersistentFlags().StringVarP(&o.DataFile, "data-file", "", "", "set csv, json or json lines data file of template rows, each message gets the next row as .Row")
+	cmd.PersistentFlags().StringArrayVarP(&o.Tags, "tag", "", []string{}, "set message tag in key=value format, repeat to set several tags, values are rendered as templates with --body-template")
+}
+
+func (o *MessageOptions) IsSet() bool {
+	return o.BodyTemplate != ""
+}
+
+func (o *MessageOptions) Complete(metadata string) (*MessageTemplate, error) {
+	tags, err := ParseTags(o.Tags)
+	if err != nil {
+		return nil, err
+	}
+	o.tags = tags
+	if o.DataFile != "" && o.BodyTemplate == "" {
+		return nil, fmt.Errorf("--data-file requires --body-template")
+	}
+	if o.BodyTemplate == "" {
+		return nil, nil
+	}
+	return NewMessageTemplate(o.BodyTemplate, metadata, o.tags, o.DataFile)
+}
+
+// Message returns the message of a static body and metadata with the tags flags, when no body template is set
+func (o *MessageOptions) Message(body []byte, metadata string) *Message {
+	return &Message{
+		Body:     body,
+		Metadata: metadata,
+		Tags:     o.tags,
+	}
+}
+
+// ParseTags parses tags in key=value format, values may contain =
+func ParseTags(values []string) (map[string]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	tags := map[string]string{}
+	for _, value := range values {
+		idx := strings.Index(value, "=")
+		if idx <= 0 {
+			return nil, fmt.Errorf("invalid tag %s, must be in key=value format", value)
+		}
+		tags[value[:idx]] = value[idx+1:]
+	}
+	return tags, nil
+}
+
+// MessageData is the data of a message template, Index starts at 0 and Seq starts at 1
+type MessageData struct {
+	Index     int
+	Seq       int
+	Channel   string
+	Uuid      string
+	Timestamp string
+	Unix      int64
+	UnixNano  int64
+	Row       map[string]interface{}
+}
+
+// Message is a rendered message
+type Message struct {
+	Body     []byte
+	Metadata string
+	Tags     map[string]string
+}
+
+// MessageTemplate renders the body, metadata and tags of each message, data file rows are assigned to messages round robin
+type MessageTemplate struct {
+	body     *template.Template
+	metadata *template.Template
+	tags     map[string]*template.Template
+	rows     []map[string]interface{}
+}
+
+func NewMessageTemplate(body, metadata string, tags map[string]string, dataFile string) (*MessageTemplate, error) {
+	rand.Seed(time.Now().UnixNano())
+	m := &MessageTemplate{
+		tags: map[string]*template.Template{},
+	}
+	var err error
+	if m.body, err = parse("body", body); err != nil {
+		return nil, err
+	}
+	if m.metadata, err = parse("metadata", metadata); err != nil {
+		return nil, err
+	}
+	for key, value := range tags {
+		if m.tags[key], err = parse(fmt.Sprintf("tag %s", key), value); err != nil {
+			return nil, err
+		}
+	}
+	if dataFile != "" {
+		if m.rows, err = LoadRows(dataFile); err != nil {
+			return nil, fmt.Errorf("load data file, %s", err.Error())
+		}
+		if len(m.rows) == 0 {
+			return nil, fmt.Errorf("data file %s has no rows", dataFile)
+		}
+	}
+	return m, nil
+}
+
+// messageFuncs are the sprig functions and randInt, which sprig v2 does not provide
+func messageFuncs() template.FuncMap {
+	funcs := sprig.TxtFuncMap()
+	funcs["randInt"] = func(min, max int) int {
+		if max <= min {
+			return min
+		}
+		return min + rand.Intn(max-min)
+	}
+	return funcs
+}
+
+func parse(name, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Funcs(messageFuncs()).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s template, %s", name, err.Error())
+	}
+	return tmpl, nil
+}
+
+func (m *MessageTemplate) Render(channel string, index int) (*Message, error) {
+	now := time.Now()
+	data := &MessageData{
+		Index:     index,
+		Seq:       index + 1,
+		Channel:   channel,
+		Uuid:      uuid.New().String(),
+		Timestamp: now.UTC().Format(time.RFC3339Nano),
+		Unix:      now.Unix(),
+		UnixNano:  now.UnixNano(),
+	}
+	if len(m.rows) > 0 {
+		data.Row = m.rows[index%len(m.rows)]
+	}
+	body, err := execute(m.body, data)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := execute(m.metadata, data)
+	if err != nil {
+		return nil, err
+	}
+	msg := &Message{
+		Body:     body,
+		Metadata: string(metadata),
+	}
+	if len(m.tags) > 0 {
+		msg.Tags = map[string]string{}
+		for key, tmpl := range m.tags {
+			value, err := execute(tmpl, data)
+			if err != nil {
+				return nil, err
+			}
+			msg.Tags[key] = string(value)
+		}
+	}
+	return msg, nil
+}
+
+func execute(tmpl *template.Template, data *MessageData) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := tmpl.Execute(buf, data); err != nil {
+		return nil, fmt.Errorf("render %s template, %s", tmpl.Name(), err.Error())
+	}
+	return buf.Bytes(), nil
+}
+
+// LoadRows loads the rows of a csv file with a header line, a json array of objects or a json lines file
+func LoadRows(fileName string) ([]map[string]interface{}, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv":
+		return csvRows(f)
+	case ".json":
+		var rows []map[string]interface{}
+		if err := json.NewDecoder(f).Decode(&rows); err != nil {
+			return nil, fmt.Errorf("invalid json data file, must be an array of objects, %s", err.Error())
+		}
+		return rows, nil
+	default:
+		return jsonLinesRows(f)
+	}
+}
+
+func csvRows(r io.Reader) ([]map[string]interface{}, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid csv data file, %s", err.Error())
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+	header := records[0]
+	var rows []map[string]interface{}
+	for _, record := range records[1:] {
+		row := map[string]interface{}{}
+		for i, name := range header {
+			if i < len(record) {
+				row[name] = record[i]
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func jsonLinesRows(r io.Reader) ([]map[string]interface{}, error) {
+	var rows []map[string]interface{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		row := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(text), &row); err != nil {
+			return nil, fmt.Errorf("invalid json lines data file, line %d, %s", line, err.Error())
+		}
+		rows = append(rows, row)
+	}
+	return rows, scanner.Err()
+}
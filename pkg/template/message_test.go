@@ -0,0 +1,75 @@
+package template
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageTemplate_Render(t *testing.T) {
+	m, err := NewMessageTemplate(`{"seq":{{ .Seq }},"channel":"{{ .Channel }}","id":"{{ .Uuid }}"}`, "m-{{ .Index }}", map[string]string{"partition": "{{ mod .Index 2 }}"}, "")
+	require.NoError(t, err)
+	first, err := m.Render("ch1", 0)
+	require.NoError(t, err)
+	second, err := m.Render("ch1", 1)
+	require.NoError(t, err)
+	require.Contains(t, string(first.Body), `"seq":1,"channel":"ch1"`)
+	require.NotEqual(t, first.Body, second.Body)
+	require.Equal(t, "m-1", second.Metadata)
+	require.Equal(t, map[string]string{"partition": "0"}, first.Tags)
+	require.Equal(t, map[string]string{"partition": "1"}, second.Tags)
+
+	m, err = NewMessageTemplate(`{{ randInt 5 7 }} {{ randAlphaNum 4 | len }}`, "", nil, "")
+	require.NoError(t, err)
+	msg, err := m.Render("ch1", 0)
+	require.NoError(t, err)
+	require.Contains(t, []string{"5 4", "6 4"}, string(msg.Body))
+
+	_, err = NewMessageTemplate("{{ .Seq", "", nil, "")
+	require.Error(t, err)
+}
+
+func TestMessageTemplate_Rows(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"rows.csv":   "user,amount\nalice,10\nbob,20\n",
+		"rows.json":  `[{"user":"alice","amount":10},{"user":"bob","amount":20}]`,
+		"rows.jsonl": "{\"user\":\"alice\",\"amount\":10}\n\n{\"user\":\"bob\",\"amount\":20}\n",
+	}
+	for name, content := range files {
+		fileName := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(fileName, []byte(content), 0644))
+		m, err := NewMessageTemplate("{{ .Row.user }}:{{ .Row.amount }}", "", nil, fileName)
+		require.NoError(t, err, name)
+		for i, want := range []string{"alice:10", "bob:20", "alice:10"} {
+			msg, err := m.Render("ch1", i)
+			require.NoError(t, err, name)
+			require.Equal(t, want, string(msg.Body), name)
+		}
+		m, err = NewMessageTemplate("{{ .Row.missing }}", "", nil, fileName)
+		require.NoError(t, err, name)
+		_, err = m.Render("ch1", 0)
+		require.Error(t, err, name)
+	}
+}
+
+func TestMessageOptions_Tags(t *testing.T) {
+	o := &MessageOptions{Tags: []string{"region=eu", "query=a=b"}}
+	tmpl, err := o.Complete("")
+	require.NoError(t, err)
+	require.Nil(t, tmpl)
+	msg := o.Message([]byte("body"), "meta")
+	require.Equal(t, map[string]string{"region": "eu", "query": "a=b"}, msg.Tags)
+
+	o = &MessageOptions{BodyTemplate: "{{ .Seq }}", Tags: []string{"seq=s-{{ .Seq }}"}}
+	tmpl, err = o.Complete("")
+	require.NoError(t, err)
+	msg, err = tmpl.Render("ch1", 1)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"seq": "s-2"}, msg.Tags)
+
+	_, err = (&MessageOptions{Tags: []string{"=eu"}}).Complete("")
+	require.Error(t, err)
+}
@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var stdin io.Reader = os.Stdin
+
+// BodyOptions are the flags of the send commands which load the message body without prompts
+type BodyOptions struct {
+	File   string
+	Base64 bool
+}
+
+func (o *BodyOptions) AddFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&o.File, "body-file", "", "", "set load message body from file, - reads the body from stdin")
+	cmd.PersistentFlags().BoolVarP(&o.Base64, "body-base64", "", false, "set decode message body from base64")
+}
+
+// Body returns the message body of the body argument, - reads the body from stdin, @path reads the body from a file and @@ escapes a body which starts with @.
+// Bodies are read byte exact, and decoded from base64 when --body-base64 is set.
+func (o *BodyOptions) Body(args []string) ([]byte, error) {
+	var body []byte
+	var err error
+	switch {
+	case o.File != "" && len(args) > 0:
+		return nil, fmt.Errorf("only one of body argument or --body-file can be set")
+	case o.File != "":
+		body, err = readBody(o.File)
+	case len(args) == 0:
+		return nil, fmt.Errorf("missing body argument")
+	case args[0] == "-":
+		body, err = readBody("-")
+	case strings.HasPrefix(args[0], "@@"):
+		body = []byte(args[0][1:])
+	case strings.HasPrefix(args[0], "@"):
+		body, err = readBody(args[0][1:])
+	default:
+		body = []byte(args[0])
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !o.Base64 {
+		return body, nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(body)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64 body, %s", err.Error())
+	}
+	return decoded, nil
+}
+
+func readBody(fileName string) ([]byte, error) {
+	if fileName == "-" {
+		data, err := ioutil.ReadAll(stdin)
+		if err != nil {
+			return nil, fmt.Errorf("read body from stdin, %s", err.Error())
+		}
+		return data, nil
+	}
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("read body file, %s", err.Error())
+	}
+	return data, nil
+}
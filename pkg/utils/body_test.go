@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBodyOptions_Body(t *testing.T) {
+	binary := []byte{0x00, 0xff, 0x10, '\n', 0x80}
+	fileName := filepath.Join(t.TempDir(), "body.bin")
+	require.NoError(t, os.WriteFile(fileName, binary, 0644))
+	b64Name := filepath.Join(t.TempDir(), "body.b64")
+	require.NoError(t, os.WriteFile(b64Name, []byte("aGVsbG8=\n"), 0644))
+
+	tests := []struct {
+		name    string
+		opts    BodyOptions
+		args    []string
+		stdin   string
+		want    []byte
+		wantErr bool
+	}{
+		{name: "argument", args: []string{"some-body"}, want: []byte("some-body")},
+		{name: "file argument", args: []string{"@" + fileName}, want: binary},
+		{name: "escaped argument", args: []string{"@@user"}, want: []byte("@user")},
+		{name: "stdin argument", args: []string{"-"}, stdin: "from\x00stdin", want: []byte("from\x00stdin")},
+		{name: "body file", opts: BodyOptions{File: fileName}, want: binary},
+		{name: "body file stdin", opts: BodyOptions{File: "-"}, stdin: "piped", want: []byte("piped")},
+		{name: "base64 argument", opts: BodyOptions{Base64: true}, args: []string{"aGVsbG8="}, want: []byte("hello")},
+		{name: "base64 file", opts: BodyOptions{File: b64Name, Base64: true}, want: []byte("hello")},
+		{name: "invalid base64", opts: BodyOptions{Base64: true}, args: []string{"not base64"}, wantErr: true},
+		{name: "missing body", wantErr: true},
+		{name: "body file and argument", opts: BodyOptions{File: fileName}, args: []string{"some-body"}, wantErr: true},
+		{name: "missing file", args: []string{"@" + fileName + ".missing"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdin = strings.NewReader(tt.stdin)
+			got, err := tt.opts.Body(tt.args)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -7,10 +7,24 @@ import (
 	"strings"
 )
 
+// ExitError is an error which exits the process with a specific exit code, i.e. an alert check failure
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
 func CheckErr(err error, cmd ...*cobra.Command) {
 	if err == nil {
 		return
 	}
+	code := 1
+	if exitErr, ok := err.(*ExitError); ok {
+		code = exitErr.Code
+	}
 	msg := err.Error()
 	if !strings.HasPrefix(msg, "error: ") {
 		msg = fmt.Sprintf("error: %s", msg)
@@ -21,5 +35,5 @@ func CheckErr(err error, cmd ...*cobra.Command) {
 			fmt.Fprintln(os.Stderr, "Try:", cmd[0].Example)
 		}
 	}
-	os.Exit(1)
+	os.Exit(code)
 }
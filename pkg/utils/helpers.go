@@ -2,9 +2,18 @@ package utils
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"strings"
 )
 
+var out io.Writer = os.Stdout
+
+// SetOutput sets the writer of the status messages, default is stdout
+func SetOutput(w io.Writer) {
+	out = w
+}
+
 func Title(input string) string {
 	words := strings.Fields(input)
 	if len(words) > 0 {
@@ -13,25 +22,39 @@ func Title(input string) string {
 	return strings.Join(words, " ")
 }
 func Println(msg string) {
-	fmt.Println(Title(msg))
+	fmt.Fprintln(out, Title(msg))
 }
 
 func Print(msg string) {
-	fmt.Print(Title(msg))
+	fmt.Fprint(out, Title(msg))
 }
 func Printf(format string, args ...interface{}) {
-	fmt.Print(Title(fmt.Sprintf(format, args...)))
+	fmt.Fprint(out, Title(fmt.Sprintf(format, args...)))
 }
 func Printlnf(format string, args ...interface{}) {
-	fmt.Println(Title(fmt.Sprintf(format, args...)))
+	fmt.Fprintln(out, Title(fmt.Sprintf(format, args...)))
 }
 func PrintlnfNoTitle(format string, args ...interface{}) {
-	fmt.Println(fmt.Sprintf(format, args...))
+	fmt.Fprintln(out, fmt.Sprintf(format, args...))
 }
 func PrintAndExit(msg string) {
-	fmt.Println(Title(msg))
+	fmt.Fprintln(out, Title(msg))
 }
 
 func PrintfAndExit(format string, args ...interface{}) {
-	fmt.Println(Title(fmt.Sprintf(format, args...)))
+	fmt.Fprintln(out, Title(fmt.Sprintf(format, args...)))
+}
+
+// EnvKey converts a key into an environment variable name, i.e. order-id to ORDER_ID
+func EnvKey(key string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, key)
 }
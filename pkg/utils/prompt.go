@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+var noInput bool
+
+// SetNoInput disables all interactive prompts, any prompt fails with an error naming the input which sets its value
+func SetNoInput(value bool) {
+	noInput = value
+}
+
+// IsNoInput returns true when interactive prompts are disabled
+func IsNoInput() bool {
+	return noInput
+}
+
+// AskOne prompts for a single value, input names the flag or argument which sets the value without a prompt, i.e. "--cluster flag"
+func AskOne(p survey.Prompt, response interface{}, input string, opts ...survey.AskOpt) error {
+	if noInput {
+		return MissingInput(input)
+	}
+	return survey.AskOne(p, response, opts...)
+}
+
+// Ask prompts for a set of questions, input names the flag or argument which sets the values without a prompt
+func Ask(qs []*survey.Question, response interface{}, input string, opts ...survey.AskOpt) error {
+	if noInput {
+		return MissingInput(input)
+	}
+	return survey.Ask(qs, response, opts...)
+}
+
+// SelectOne returns value when set, otherwise prompts to select one of the options. A single option is selected without a prompt
+func SelectOne(value string, options []string, message, input string) (string, error) {
+	if value != "" {
+		if contains(options, value) {
+			return value, nil
+		}
+		return "", fmt.Errorf("invalid %s value %s, valid values: %s", input, value, strings.Join(options, ", "))
+	}
+	if len(options) == 1 {
+		return options[0], nil
+	}
+	selection := ""
+	prompt := &survey.Select{
+		Renderer: survey.Renderer{},
+		Message:  message,
+		Options:  options,
+		Default:  options[0],
+	}
+	if err := AskOne(prompt, &selection, input); err != nil {
+		return "", err
+	}
+	return selection, nil
+}
+
+// SelectMany returns values when set, otherwise prompts to select some of the options
+func SelectMany(values []string, options []string, message, input string) ([]string, error) {
+	if len(values) > 0 {
+		for _, value := range values {
+			if !contains(options, value) {
+				return nil, fmt.Errorf("invalid %s value %s, valid values: %s", input, value, strings.Join(options, ", "))
+			}
+		}
+		return values, nil
+	}
+	selection := []string{}
+	prompt := &survey.MultiSelect{
+		Renderer: survey.Renderer{},
+		Message:  message,
+		Options:  options,
+		Help:     message,
+	}
+	if err := AskOne(prompt, &selection, input); err != nil {
+		return nil, err
+	}
+	return selection, nil
+}
+
+// Confirm returns true when yes is set, otherwise prompts for confirmation
+func Confirm(yes bool, help string) (bool, error) {
+	if yes {
+		return true, nil
+	}
+	areYouSure := false
+	prompt := &survey.Confirm{
+		Renderer: survey.Renderer{},
+		Message:  "Are you sure ?",
+		Default:  false,
+		Help:     help,
+	}
+	if err := AskOne(prompt, &areYouSure, "--yes flag"); err != nil {
+		return false, err
+	}
+	return areYouSure, nil
+}
+
+func contains(options []string, value string) bool {
+	for _, option := range options {
+		if option == value {
+			return true
+		}
+	}
+	return false
+}
+
+// MissingInput returns the error of a value which is not set by input in --no-input mode
+func MissingInput(input string) error {
+	return fmt.Errorf("missing %s, interactive input is disabled by --no-input", input)
+}
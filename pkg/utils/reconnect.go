@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	reconnectMinDelay = time.Second
+	reconnectMaxDelay = 30 * time.Second
+)
+
+type backoff struct {
+	min     time.Duration
+	max     time.Duration
+	attempt int
+}
+
+func newBackoff(min, max time.Duration) *backoff {
+	return &backoff{
+		min: min,
+		max: max,
+	}
+}
+
+// next returns the next delay, the delay is doubled on each attempt up to the max delay
+func (b *backoff) next() time.Duration {
+	d := b.min
+	for i := 0; i < b.attempt && d < b.max; i++ {
+		d *= 2
+	}
+	if d > b.max {
+		d = b.max
+	}
+	b.attempt++
+	return d
+}
+
+func (b *backoff) reset() {
+	b.attempt = 0
+}
+
+type stopError struct {
+	err error
+}
+
+func (e *stopError) Error() string {
+	return e.err.Error()
+}
+
+// StopReconnect wraps an error which Reconnect should not retry, i.e. an interactive prompt error
+func StopReconnect(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &stopError{err: err}
+}
+
+// Reconnect runs connect until ctx is done or connect returns a StopReconnect error. When connect returns, it runs again after an exponential backoff delay, the delay is reset once a connection lasted longer than the max delay
+func Reconnect(ctx context.Context, name string, connect func(ctx context.Context) error) error {
+	b := newBackoff(reconnectMinDelay, reconnectMaxDelay)
+	for {
+		start := time.Now()
+		err := connect(ctx)
+		if stop, ok := err.(*stopError); ok {
+			return stop.err
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+		if time.Since(start) > b.max {
+			b.reset()
+		}
+		delay := b.next()
+		if err != nil {
+			Printlnf("%s disconnected, %s, reconnecting in %s...", name, err.Error(), delay)
+		} else {
+			Printlnf("%s disconnected, reconnecting in %s...", name, delay)
+		}
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
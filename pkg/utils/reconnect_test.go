@@ -0,0 +1,19 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackoff(t *testing.T) {
+	b := newBackoff(time.Second, 10*time.Second)
+	var delays []time.Duration
+	for i := 0; i < 6; i++ {
+		delays = append(delays, b.next())
+	}
+	require.Equal(t, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second}, delays)
+	b.reset()
+	require.Equal(t, time.Second, b.next())
+}